@@ -0,0 +1,176 @@
+package validator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+const baselineVersion = 1
+
+// BaselineEntry identifies a finding that is known and should not be reported.
+// It holds no position, so the entry keeps matching when unrelated code moves around.
+type BaselineEntry struct {
+	Struct string `json:"struct"`
+	Field  string `json:"field"`
+	Tag    string `json:"tag"`
+	Hash   string `json:"hash"`
+}
+
+type baselineFile struct {
+	Version int                 `json:"version"`
+	Entries []baselineFileEntry `json:"entries"`
+}
+
+type baselineFileEntry struct {
+	BaselineEntry
+	Count int `json:"count,omitempty"`
+}
+
+func newBaselineEntry(e *ValidationError) BaselineEntry {
+	hash := sha256.Sum256([]byte(e.Message))
+
+	return BaselineEntry{
+		Struct: e.Struct,
+		Field:  e.Field,
+		Tag:    e.Tag,
+		Hash:   hex.EncodeToString(hash[:8]),
+	}
+}
+
+func sortBaselineEntries(entries []baselineFileEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+
+		if a.Struct != b.Struct {
+			return a.Struct < b.Struct
+		}
+
+		if a.Field != b.Field {
+			return a.Field < b.Field
+		}
+
+		if a.Tag != b.Tag {
+			return a.Tag < b.Tag
+		}
+
+		return a.Hash < b.Hash
+	})
+}
+
+// WriteBaseline writes the findings of the last run, including the suppressed ones, as a baseline.
+func (v *Validator) WriteBaseline(w io.Writer) error {
+	counts := map[BaselineEntry]int{}
+	v.mu.Lock()
+
+	for _, finding := range v.findings {
+		counts[newBaselineEntry(finding)]++
+	}
+
+	v.mu.Unlock()
+
+	file := baselineFile{
+		Version: baselineVersion,
+		Entries: make([]baselineFileEntry, 0, len(counts)),
+	}
+
+	for entry, count := range counts {
+		file.Entries = append(file.Entries, baselineFileEntry{entry, count})
+	}
+
+	sortBaselineEntries(file.Entries)
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(file)
+}
+
+// SetBaseline loads a baseline written by WriteBaseline.
+// Findings matching an entry of the baseline are suppressed and counted in Stats.
+func (v *Validator) SetBaseline(r io.Reader) error {
+	file := baselineFile{}
+
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return fmt.Errorf("invalid baseline: %v", err)
+	}
+
+	if file.Version != baselineVersion {
+		return fmt.Errorf("unsupported baseline version %v", file.Version)
+	}
+
+	baseline := make(map[BaselineEntry]int, len(file.Entries))
+
+	for _, entry := range file.Entries {
+		if entry.Count < 1 {
+			entry.Count = 1
+		}
+
+		baseline[entry.BaselineEntry] += entry.Count
+	}
+
+	v.baseline = baseline
+
+	return nil
+}
+
+// StaleBaselineEntries returns the baseline entries that matched no finding in the last run.
+// They can be pruned from the baseline.
+func (v *Validator) StaleBaselineEntries() []BaselineEntry {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	return append([]BaselineEntry{}, v.staleBaseline...)
+}
+
+// suppressBaseline records the findings and leaves out the ones matching the baseline.
+func (v *Validator) suppressBaseline(errs []error) []*ValidationError {
+	remaining := make(map[BaselineEntry]int, len(v.baseline))
+
+	for entry, count := range v.baseline {
+		remaining[entry] = count
+	}
+
+	v.findings = []*ValidationError{}
+	reported := []*ValidationError{}
+
+	for _, err := range errs {
+		var finding *ValidationError
+
+		if !errors.As(err, &finding) {
+			finding = &ValidationError{Message: err.Error(), err: err}
+		}
+
+		v.findings = append(v.findings, finding)
+		entry := newBaselineEntry(finding)
+
+		if remaining[entry] > 0 {
+			remaining[entry]--
+			v.stats.Suppressed++
+			continue
+		}
+
+		reported = append(reported, finding)
+	}
+
+	stale := []baselineFileEntry{}
+
+	for entry, count := range remaining {
+		if count > 0 {
+			stale = append(stale, baselineFileEntry{BaselineEntry: entry})
+		}
+	}
+
+	sortBaselineEntries(stale)
+	v.staleBaseline = make([]BaselineEntry, 0, len(stale))
+
+	for _, entry := range stale {
+		v.staleBaseline = append(v.staleBaseline, entry.BaselineEntry)
+	}
+
+	return reported
+}
@@ -0,0 +1,92 @@
+package validator
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_testBaseline(t *testing.T) {
+	r := require.New(t)
+
+	createModel("customer.go", []structTpl{
+		{
+			"Customer",
+			"created_at",
+			"created_at",
+			"",
+		},
+		{
+			"Customer1",
+			"created_at_",
+			"updated_at",
+			"",
+		},
+	})
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("db")
+
+	r.Len(m.Run(), 2)
+
+	baseline := &bytes.Buffer{}
+	r.NoError(m.WriteBaseline(baseline))
+	r.NotContains(baseline.String(), "customer.go")
+
+	m = NewValidator(modelsPath)
+	m.AddDefaultProcessors("db")
+	r.NoError(m.SetBaseline(bytes.NewReader(baseline.Bytes())))
+
+	r.Empty(m.Run())
+	r.Equal(2, m.Stats().Suppressed)
+	r.Empty(m.StaleBaselineEntries())
+
+	//Moving the structs around keeps them suppressed, new findings are reported
+	createModel("customer.go", []structTpl{
+		{
+			"Customer2",
+			"updated_at",
+			"updated_at",
+			"",
+		},
+		{
+			"Customer1",
+			"created_at_",
+			"updated_at",
+			"",
+		},
+		{
+			"Customer",
+			"created_at",
+			"updated_at",
+			"",
+		},
+	})
+
+	errs := m.Run()
+
+	r.Len(errs, 1)
+	r.Contains(errs[0].Error(), "Customer2")
+	r.Equal(1, m.Stats().Suppressed)
+
+	stale := m.StaleBaselineEntries()
+
+	r.Len(stale, 1)
+	r.Equal("Customer", stale[0].Struct)
+	r.Equal("UpdatedAt", stale[0].Field)
+	r.Equal("db", stale[0].Tag)
+}
+
+func Test_testBaselineInvalid(t *testing.T) {
+	r := require.New(t)
+
+	m := NewValidator(modelsPath)
+
+	r.Error(m.SetBaseline(strings.NewReader("{")))
+	r.Error(m.SetBaseline(strings.NewReader(`{"version": 2, "entries": []}`)))
+	r.NoError(m.SetBaseline(strings.NewReader(`{"version": 1, "entries": []}`)))
+}
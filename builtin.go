@@ -0,0 +1,258 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// NewMaxLengthProcessor creates a processor reporting tag values whose name is longer than max characters.
+// Options after the first comma aren't counted, e.g. `db:"name,omitempty"` is 4 characters long.
+func NewMaxLengthProcessor(max int) func(tag *Tag) []error {
+	return func(tag *Tag) []error {
+		errs := []error{}
+		name, _, _ := strings.Cut(tag.GetValue(), ",")
+
+		if length := utf8.RuneCountInString(name); length > max {
+			errs = append(errs, fmt.Errorf("Tag value %v in %v.%v is %v characters long, the maximum is %v",
+				name, tag.GetStructName(), tag.GetName(), length, max))
+		}
+
+		return errs
+	}
+}
+
+// sqlReservedWords are reserved by the SQL standard and every supported dialect.
+var sqlReservedWords = []string{
+	"all", "and", "any", "as", "asc", "between", "by", "case", "check", "column", "constraint", "create",
+	"cross", "default", "delete", "desc", "distinct", "drop", "else", "end", "exists", "false", "for",
+	"foreign", "from", "full", "group", "having", "in", "inner", "insert", "into", "is", "join", "left",
+	"like", "not", "null", "on", "or", "order", "outer", "primary", "references", "right", "select",
+	"set", "table", "then", "to", "true", "union", "unique", "update", "user", "using", "values", "when",
+	"where", "with",
+}
+
+// reservedWords holds the words reserved by each dialect on top of the SQL ones.
+var reservedWords = map[string][]string{
+	"sql": {},
+	"postgres": {
+		"analyse", "analyze", "array", "asymmetric", "both", "cast", "collate", "current_date",
+		"current_role", "current_time", "current_timestamp", "current_user", "deferrable", "do", "except",
+		"fetch", "grant", "initially", "intersect", "lateral", "leading", "limit", "localtime",
+		"localtimestamp", "offset", "only", "placing", "returning", "session_user", "some", "symmetric",
+		"trailing", "variadic", "window",
+	},
+	"mysql": {
+		"add", "alter", "before", "change", "condition", "database", "databases", "div", "dual", "explain",
+		"force", "index", "interval", "key", "keys", "kill", "limit", "lock", "match", "mod", "option",
+		"range", "read", "regexp", "rename", "replace", "require", "schema", "show", "status", "usage",
+		"write", "xor",
+	},
+	"sqlite": {
+		"abort", "add", "after", "alter", "attach", "autoincrement", "before", "begin", "cast", "collate",
+		"commit", "conflict", "database", "detach", "each", "escape", "except", "exclusive", "glob",
+		"index", "indexed", "instead", "intersect", "key", "limit", "match", "offset", "pragma", "raise",
+		"reindex", "rename", "replace", "rollback", "row", "savepoint", "temp", "trigger", "vacuum", "view",
+	},
+}
+
+// Dialects returns the names of the SQL dialects known to NewReservedWordsProcessor.
+func Dialects() []string {
+	dialects := make([]string, 0, len(reservedWords))
+
+	for dialect := range reservedWords {
+		dialects = append(dialects, dialect)
+	}
+
+	sort.Strings(dialects)
+
+	return dialects
+}
+
+// NewReservedWordsProcessor creates a processor reporting tag values which are reserved words of the SQL dialect,
+// one of sql, postgres, mysql and sqlite. Words are matched regardless of their case.
+func NewReservedWordsProcessor(dialect string) (func(tag *Tag) []error, error) {
+	words, exists := reservedWords[dialect]
+
+	if !exists {
+		return nil, fmt.Errorf("Unknown dialect %v, expected one of %v", dialect, strings.Join(Dialects(), ", "))
+	}
+
+	reserved := make(map[string]bool, len(sqlReservedWords)+len(words))
+
+	for _, word := range append(append([]string{}, sqlReservedWords...), words...) {
+		reserved[word] = true
+	}
+
+	return func(tag *Tag) []error {
+		errs := []error{}
+		name, _, _ := strings.Cut(tag.GetValue(), ",")
+
+		if reserved[strings.ToLower(name)] {
+			errs = append(errs, fmt.Errorf("Tag value %v in %v.%v is a reserved word in %v", name, tag.GetStructName(), tag.GetName(), dialect))
+		}
+
+		return errs
+	}, nil
+}
+
+// NewConventionProcessor creates a struct processor reporting the required values a struct is missing
+// and the unique values more than one of its fields holds, e.g. every model has one id column and its timestamps:
+//
+//	m.AddStructProcessor("db", NewConventionProcessor([]string{"id", "created_at", "updated_at"}, []string{"id"}, "*Link"))
+//
+// Options after the first comma aren't compared. Structs matching one of the exclude patterns, e.g. join tables, are left out.
+func NewConventionProcessor(required []string, unique []string, exclude ...string) func(s *StructInfo) []error {
+	return func(s *StructInfo) []error {
+		errs := []error{}
+
+		for _, pattern := range exclude {
+			if ok, _ := path.Match(pattern, s.Name); ok {
+				return errs
+			}
+		}
+
+		fields := map[string][]*Tag{}
+
+		for _, t := range s.Tags {
+			name, _, _ := strings.Cut(t.GetValue(), ",")
+			fields[name] = append(fields[name], t)
+		}
+
+		for _, value := range required {
+			if len(fields[value]) == 0 {
+				errs = append(errs, fmt.Errorf("Struct %v is missing the tag value %v", s.Name, value))
+			}
+		}
+
+		for _, value := range unique {
+			holders := fields[value]
+
+			if len(holders) < 2 {
+				continue
+			}
+
+			names := make([]string, 0, len(holders))
+
+			for _, t := range holders {
+				names = append(names, t.GetFieldName())
+			}
+
+			//Reported at the first field which shouldn't hold the value
+			errs = append(errs, newValidationError(holders[1], fmt.Errorf("Tag value %v in %v.%v must be unique, it is held by %v",
+				value, s.Name, holders[1].GetName(), strings.Join(names, ", "))))
+		}
+
+		return errs
+	}
+}
+
+// NewOrderProcessor creates a struct processor reporting the fields which are out of place,
+// the first values must open the struct in the given order and the last values must close it, e.g. the id first and the timestamps last:
+//
+//	m.AddStructProcessor("db", NewOrderProcessor([]string{"id"}, []string{"created_at", "updated_at"}))
+//
+// Options after the first comma aren't compared. A struct missing one of the first values isn't checked for them, the same goes for the last ones,
+// see NewConventionProcessor to require them.
+func NewOrderProcessor(first []string, last []string) func(s *StructInfo) []error {
+	expected := strings.Join(append(append(append([]string{}, first...), "..."), last...), ", ")
+
+	return func(s *StructInfo) []error {
+		errs := []error{}
+		positions := map[string]int{}
+
+		for i, t := range s.Tags {
+			name, _, _ := strings.Cut(t.GetValue(), ",")
+
+			if _, exists := positions[name]; !exists {
+				positions[name] = i
+			}
+		}
+
+		check := func(values []string, offset int) {
+			for _, value := range values {
+				if _, exists := positions[value]; !exists {
+					return
+				}
+			}
+
+			for i, value := range values {
+				at := positions[value]
+
+				if at == offset+i {
+					continue
+				}
+
+				t := s.Tags[at]
+				err := fmt.Errorf("Field %v with the tag value %v in %v.%v is at position %v, expected at position %v",
+					t.GetFieldName(), value, s.Name, t.GetName(), at+1, offset+i+1)
+				errs = append(errs, newValidationError(t, NewErrorWithSuggestion(err, "order the fields as "+expected)))
+			}
+		}
+
+		check(first, 0)
+		check(last, len(s.Tags)-len(last))
+
+		return errs
+	}
+}
+
+// NewEnumProcessor creates a processor reporting tag values which aren't one of the allowed ones, e.g. for `scope:"public"`.
+// Options after the first comma aren't compared, the nearest allowed value is suggested. Values are case sensitive, see NewEnumProcessorFold.
+func NewEnumProcessor(allowed ...string) (func(tag *Tag) []error, error) {
+	return newEnumProcessor(allowed, false)
+}
+
+// NewEnumProcessorFold works like NewEnumProcessor, but values are compared regardless of their case.
+func NewEnumProcessorFold(allowed ...string) (func(tag *Tag) []error, error) {
+	return newEnumProcessor(allowed, true)
+}
+
+func newEnumProcessor(allowed []string, fold bool) (func(tag *Tag) []error, error) {
+	if len(allowed) == 0 {
+		return nil, errors.New("The enum needs at least one allowed value")
+	}
+
+	normalize := func(value string) string {
+		if fold {
+			return strings.ToLower(value)
+		}
+
+		return value
+	}
+
+	//values maps the normalized values to the allowed ones, so suggestions keep their spelling
+	values := make(map[string]string, len(allowed))
+	candidates := make(map[string]bool, len(allowed))
+	longest := 0
+
+	for _, value := range allowed {
+		values[normalize(value)] = value
+		candidates[normalize(value)] = true
+		longest = max(longest, utf8.RuneCountInString(value))
+	}
+
+	return func(tag *Tag) []error {
+		errs := []error{}
+		name, options, hasOptions := strings.Cut(tag.GetValue(), ",")
+
+		if _, exists := values[normalize(name)]; exists {
+			return errs
+		}
+
+		err := fmt.Errorf("Tag value %v in %v.%v is not one of %v", name, tag.GetStructName(), tag.GetName(), strings.Join(allowed, ", "))
+		known, _ := nearest(normalize(name), candidates, longest+utf8.RuneCountInString(name))
+		suggestion := values[known]
+
+		if hasOptions {
+			suggestion += "," + options
+		}
+
+		errs = append(errs, NewErrorWithSuggestion(err, suggestion))
+
+		return errs
+	}, nil
+}
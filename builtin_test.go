@@ -0,0 +1,224 @@
+package validator
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var conventionModel = `package models
+
+type Customer struct {
+	ID        int    ` + "`" + `db:"id"` + "`" + `
+	CreatedAt string ` + "`" + `db:"created_at"` + "`" + `
+	UpdatedAt string ` + "`" + `db:"updated_at,omitempty"` + "`" + `
+}
+
+type Order struct {
+	ID        int    ` + "`" + `db:"id"` + "`" + `
+	CreatedAt string ` + "`" + `db:"created_at"` + "`" + `
+}
+
+type Invoice struct {
+	ID        int    ` + "`" + `db:"id"` + "`" + `
+	UUID      string ` + "`" + `db:"id,omitempty"` + "`" + `
+	CreatedAt string ` + "`" + `db:"created_at"` + "`" + `
+	UpdatedAt string ` + "`" + `db:"updated_at"` + "`" + `
+}
+
+type CustomerOrderLink struct {
+	CustomerID int ` + "`" + `db:"customer_id"` + "`" + `
+	OrderID    int ` + "`" + `db:"order_id"` + "`" + `
+}
+`
+
+func Test_testConventionProcessor(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", conventionModel)
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.SetAllowDuplicates(true)
+	m.AddStructProcessor("db", NewConventionProcessor([]string{"id", "created_at", "updated_at"}, []string{"id"}, "*Link"))
+
+	result, err := m.Validate()
+
+	r.NoError(err)
+
+	messages := map[string]*ValidationError{}
+
+	for _, finding := range result.Findings {
+		messages[finding.Message] = finding
+	}
+
+	r.Len(messages, 2)
+
+	missing := messages["Struct Order is missing the tag value updated_at"]
+	r.NotNil(missing)
+	r.Equal("Order", missing.Struct)
+	r.Equal("db", missing.Tag)
+	r.Equal("NewConventionProcessor", missing.Processor)
+
+	unique := messages["Tag value id in Invoice.db must be unique, it is held by ID, UUID"]
+	r.NotNil(unique)
+	r.Equal("UUID", unique.Field)
+	r.Equal(16, unique.Pos.Line)
+}
+
+func Test_testConventionProcessorConfig(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", conventionModel)
+	defer os.RemoveAll("./models")
+
+	m := NewValidator("unused")
+
+	r.NoError(m.LoadConfig(strings.NewReader(`
+path: ` + modelsPath + `
+allow_duplicates: ["*"]
+processors:
+  - name: conventions
+    tags: [db]
+    args:
+      required: [id, created_at, updated_at]
+      unique: [id]
+      exclude: ["*Link"]
+`)))
+
+	result, err := m.Validate()
+
+	r.NoError(err)
+	r.Len(result.Findings, 2)
+	r.Equal(map[string]int{"conventions": 2}, m.Summary())
+
+	r.EqualError(m.LoadConfig(strings.NewReader("processors:\n  - name: conventions\n")),
+		"line 2: processor conventions: The conventions need required or unique values")
+}
+
+func Test_testOrderProcessor(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", `package models
+
+type Customer struct {
+	ID        int    `+"`"+`db:"id"`+"`"+`
+	Name      string `+"`"+`db:"name"`+"`"+`
+	CreatedAt string `+"`"+`db:"created_at"`+"`"+`
+	UpdatedAt string `+"`"+`db:"updated_at,omitempty"`+"`"+`
+}
+
+type Order struct {
+	Number    int    `+"`"+`db:"number"`+"`"+`
+	ID        int    `+"`"+`db:"id"`+"`"+`
+	UpdatedAt string `+"`"+`db:"updated_at"`+"`"+`
+	CreatedAt string `+"`"+`db:"created_at"`+"`"+`
+}
+
+type Invoice struct {
+	CreatedAt string `+"`"+`db:"created_at"`+"`"+`
+	Number    int    `+"`"+`db:"number"`+"`"+`
+	UpdatedAt string `+"`"+`db:"updated_at"`+"`"+`
+}
+
+type Note struct {
+	Text      string `+"`"+`db:"text"`+"`"+`
+	CreatedAt string `+"`"+`db:"created_at"`+"`"+`
+}
+`)
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddStructProcessor("db", NewOrderProcessor([]string{"id"}, []string{"created_at", "updated_at"}))
+
+	result, err := m.Validate()
+
+	r.NoError(err)
+
+	messages := []string{}
+
+	for _, finding := range result.Findings {
+		messages = append(messages, finding.Error())
+	}
+
+	suggestion := " (suggested: order the fields as id, ..., created_at, updated_at)"
+
+	r.ElementsMatch([]string{
+		"Field ID with the tag value id in Order.db is at position 2, expected at position 1" + suggestion,
+		"Field CreatedAt with the tag value created_at in Order.db is at position 4, expected at position 3" + suggestion,
+		"Field UpdatedAt with the tag value updated_at in Order.db is at position 3, expected at position 4" + suggestion,
+		"Field CreatedAt with the tag value created_at in Invoice.db is at position 1, expected at position 2" + suggestion,
+	}, messages)
+
+	for _, finding := range result.Findings {
+		if finding.Struct == "Invoice" {
+			r.Equal("CreatedAt", finding.Field)
+			r.Equal(18, finding.Pos.Line)
+		}
+	}
+}
+
+func Test_testEnumProcessor(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", `package models
+
+type Customer struct {
+	Name    string `+"`"+`scope:"public"`+"`"+`
+	Email   string `+"`"+`scope:"pubilc,omitempty"`+"`"+`
+	Phone   string `+"`"+`scope:"Admin"`+"`"+`
+	Address string `+"`"+`scope:"internal"`+"`"+`
+}
+`)
+	defer os.RemoveAll("./models")
+
+	_, err := NewEnumProcessor()
+	r.Error(err)
+
+	scope, err := NewEnumProcessor("public", "internal", "admin")
+	r.NoError(err)
+
+	m := NewValidator(modelsPath)
+	m.AddProcessor("scope", scope)
+
+	messages := []string{}
+
+	for _, err := range m.Run() {
+		messages = append(messages, err.Error())
+	}
+
+	r.ElementsMatch([]string{
+		"Tag value pubilc in Customer.scope is not one of public, internal, admin (suggested: public,omitempty)",
+		"Tag value Admin in Customer.scope is not one of public, internal, admin (suggested: admin)",
+	}, messages)
+
+	fold, err := NewEnumProcessorFold("Public", "Internal", "Admin")
+	r.NoError(err)
+
+	m = NewValidator(modelsPath)
+	m.AddProcessor("scope", fold)
+
+	messages = []string{}
+
+	for _, err := range m.Run() {
+		messages = append(messages, err.Error())
+	}
+
+	r.Equal([]string{"Tag value pubilc in Customer.scope is not one of Public, Internal, Admin (suggested: Public,omitempty)"}, messages)
+
+	m = NewValidator("unused")
+
+	r.NoError(m.LoadConfig(strings.NewReader(`
+path: ` + modelsPath + `
+processors:
+  - name: enum
+    tags: [scope]
+    args: {values: [public, internal, admin], case_insensitive: true}
+`)))
+	r.Len(m.Run(), 1)
+
+	r.EqualError(m.LoadConfig(strings.NewReader("processors:\n  - name: enum\n    args: {values: []}\n")),
+		"line 2: processor enum: The enum needs at least one allowed value")
+}
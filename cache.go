@@ -0,0 +1,435 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// cacheVersion is stored in the cache file, a file of another version is ignored.
+const cacheVersion = 4
+
+// cacheFileName is the name of the cache file in the directory given to SetCache.
+const cacheFileName = "tagvalidator.cache"
+
+// SetCache keeps the tags collected from every file in the given directory, so later runs, also of other processes,
+// only parse the files whose size or modification time changed. An empty dir disables the cache.
+// A cache file which can't be read, e.g. written by another version, is ignored and the files are parsed again.
+// Files aren't cached while the AST is retained, see SetRetainAST.
+func (v *Validator) SetCache(dir string) {
+	if len(dir) == 0 {
+		v.cache = nil
+		return
+	}
+
+	v.cache = &fileCache{dir: dir}
+}
+
+// SetMemoryCache keeps the tags collected from every file in memory, so later runs of the validator only parse the files
+// whose size or modification time changed, e.g. when it runs on every save. See SetCache to keep them across processes.
+func (v *Validator) SetMemoryCache(enabled bool) {
+	if !enabled {
+		v.cache = nil
+		return
+	}
+
+	v.cache = &fileCache{}
+}
+
+// fileCache holds the tags collected from files by their path, along with the size and modification time they had.
+// It is shared by the snapshots of a validator, so it is guarded by its own mutex.
+type fileCache struct {
+	mu  sync.Mutex
+	dir string
+	//settings identifies the collector settings the entries were collected with, they are dropped when it changes
+	settings string
+	entries  map[string]*cacheEntry
+	loaded   bool
+	dirty    bool
+	//seen holds the files looked up since the last save, the others are checked for removal
+	seen map[string]bool
+}
+
+// cacheEntry holds what was collected from a file.
+type cacheEntry struct {
+	size     int64
+	modTime  int64
+	tags     []*Tag
+	findings []*ValidationError
+	excluded int
+}
+
+// get returns what was collected from the file unless the file changed since it was stored.
+func (fc *fileCache) get(col *collector, fileName string, info os.FileInfo) ([]*Tag, []error, int, bool) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	fc.load(col)
+
+	if fc.seen == nil {
+		fc.seen = map[string]bool{}
+	}
+
+	fc.seen[fileName] = true
+	entry, exists := fc.entries[fileName]
+
+	if !exists || entry.size != info.Size() || entry.modTime != info.ModTime().UnixNano() {
+		return nil, nil, 0, false
+	}
+
+	//Findings are copied, so the ones of a run can't change the cached ones
+	findings := make([]error, 0, len(entry.findings))
+
+	for _, finding := range entry.findings {
+		finding := *finding
+		findings = append(findings, &finding)
+	}
+
+	return entry.tags, findings, entry.excluded, true
+}
+
+// put stores what was collected from the file. Files with findings which aren't a ValidationError aren't stored.
+func (fc *fileCache) put(col *collector, fileName string, info os.FileInfo, tags []*Tag, findings []error, excluded int) {
+	entry := &cacheEntry{
+		size:     info.Size(),
+		modTime:  info.ModTime().UnixNano(),
+		tags:     tags,
+		findings: make([]*ValidationError, 0, len(findings)),
+		excluded: excluded,
+	}
+
+	for _, err := range findings {
+		finding, ok := err.(*ValidationError)
+
+		//The findings are rebuilt from the cache file, which can't hold what errors.Is and errors.As look for
+		if !ok || !isPlainError(finding.err) {
+			return
+		}
+
+		stored := *finding
+		entry.findings = append(entry.findings, &stored)
+	}
+
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	fc.load(col)
+	fc.entries[fileName] = entry
+	fc.dirty = true
+}
+
+// plainErrorType is the type of the errors made by errors.New, and fmt.Errorf without %w.
+var plainErrorType = reflect.TypeOf(errors.New(""))
+
+// isPlainError reports whether err is nil or an error carrying nothing but its message,
+// which is rebuilt the same from the cache file, unlike sentinels, chains and error types.
+func isPlainError(err error) bool {
+	return err == nil || reflect.TypeOf(err) == plainErrorType
+}
+
+// load reads the cache file once and drops the entries if the collector settings changed.
+// It must be called with the mutex held.
+func (fc *fileCache) load(col *collector) {
+	settings := col.cacheSettings
+
+	if !fc.loaded {
+		fc.loaded = true
+		fc.settings = settings
+		fc.entries = map[string]*cacheEntry{}
+
+		if len(fc.dir) > 0 {
+			if err := fc.read(); err != nil {
+				col.logger.Debug("cache ignored", "dir", fc.dir, "error", err)
+				fc.entries = map[string]*cacheEntry{}
+			}
+		}
+	}
+
+	if fc.settings != settings {
+		col.logger.Debug("cache dropped, the settings changed")
+		fc.settings = settings
+		fc.entries = map[string]*cacheEntry{}
+		fc.dirty = true
+	}
+}
+
+// save evicts the entries of the files which were removed and writes the cache file, if there is one.
+func (fc *fileCache) save(col *collector) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	for fileName := range fc.entries {
+		if fc.seen[fileName] {
+			continue
+		}
+
+		if _, err := os.Stat(fileName); errors.Is(err, os.ErrNotExist) {
+			delete(fc.entries, fileName)
+			fc.dirty = true
+		}
+	}
+
+	fc.seen = nil
+
+	if len(fc.dir) == 0 || !fc.dirty {
+		return
+	}
+
+	if err := fc.write(); err != nil {
+		col.logger.Debug("cache not written", "dir", fc.dir, "error", err)
+		return
+	}
+
+	fc.dirty = false
+}
+
+// cacheFile is the form of the cache stored on disk.
+type cacheFile struct {
+	Version  int
+	Settings string
+	Files    map[string]cachedFile
+}
+
+type cachedFile struct {
+	Size     int64
+	ModTime  int64
+	Tags     []cachedTag
+	Findings []cachedFinding
+	Excluded int
+}
+
+// cachedFinding stores the message of the error a finding wraps, see isPlainError.
+type cachedFinding struct {
+	ValidationError
+	Wraps bool
+	Err   string
+}
+
+type cachedTag struct {
+	Name       string
+	Value      string
+	Struct     string
+	Field      string
+	Pos        token.Position
+	ValueStart token.Position
+	ValueEnd   token.Position
+	DeclKey    string
+	DeclPos    token.Position
+	DeclDoc    string
+	Directives map[string]string
+	Package    string
+	PkgPath    string
+	Exported   bool
+}
+
+// read loads the entries from the cache file, a missing file leaves the cache empty.
+func (fc *fileCache) read() error {
+	data, err := os.ReadFile(filepath.Join(fc.dir, cacheFileName))
+
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	file := cacheFile{}
+
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&file); err != nil {
+		return err
+	}
+
+	if file.Version != cacheVersion {
+		return fmt.Errorf("cache version %v, expected %v", file.Version, cacheVersion)
+	}
+
+	if file.Settings != fc.settings {
+		return errors.New("the settings changed")
+	}
+
+	//Tags of one struct share their name and declaration, like collected ones
+	structNames := map[string]*string{}
+	declarations := map[string]*declaration{}
+
+	for fileName, cached := range file.Files {
+		entry := &cacheEntry{
+			size:     cached.Size,
+			modTime:  cached.ModTime,
+			tags:     make([]*Tag, 0, len(cached.Tags)),
+			findings: make([]*ValidationError, 0, len(cached.Findings)),
+			excluded: cached.Excluded,
+		}
+
+		for _, t := range cached.Tags {
+			t := t
+			structName, exists := structNames[t.Struct]
+
+			if !exists {
+				structName = &t.Struct
+				structNames[t.Struct] = structName
+			}
+
+			decl, exists := declarations[t.DeclKey]
+
+			if !exists {
+				decl = &declaration{key: t.DeclKey, pos: t.DeclPos, doc: t.DeclDoc, directives: t.Directives, pkg: t.Package, pkgPath: t.PkgPath}
+				declarations[t.DeclKey] = decl
+			}
+
+			entry.tags = append(entry.tags, &Tag{
+				name:        &t.Name,
+				value:       &t.Value,
+				structName:  structName,
+				fieldName:   &t.Field,
+				pos:         t.Pos,
+				valueStart:  t.ValueStart,
+				valueEnd:    t.ValueEnd,
+				declaration: decl,
+				exported:    t.Exported,
+			})
+		}
+
+		for _, stored := range cached.Findings {
+			finding := stored.ValidationError
+
+			if stored.Wraps {
+				finding.err = errors.New(stored.Err)
+			}
+
+			entry.findings = append(entry.findings, &finding)
+		}
+
+		fc.entries[fileName] = entry
+	}
+
+	return nil
+}
+
+// write stores the entries in the cache file, replacing it atomically.
+func (fc *fileCache) write() error {
+	file := cacheFile{
+		Version:  cacheVersion,
+		Settings: fc.settings,
+		Files:    make(map[string]cachedFile, len(fc.entries)),
+	}
+
+	for fileName, entry := range fc.entries {
+		cached := cachedFile{
+			Size:     entry.size,
+			ModTime:  entry.modTime,
+			Tags:     make([]cachedTag, 0, len(entry.tags)),
+			Findings: make([]cachedFinding, 0, len(entry.findings)),
+			Excluded: entry.excluded,
+		}
+
+		for _, t := range entry.tags {
+			var directives map[string]string
+			var pkgPath string
+
+			if t.declaration != nil {
+				directives = t.declaration.directives
+				pkgPath = t.declaration.pkgPath
+			}
+
+			cached.Tags = append(cached.Tags, cachedTag{
+				Name:       t.GetName(),
+				Value:      t.GetValue(),
+				Struct:     t.GetStructName(),
+				Field:      t.GetFieldName(),
+				Pos:        t.pos,
+				ValueStart: t.valueStart,
+				ValueEnd:   t.valueEnd,
+				DeclKey:    t.declarationKey(),
+				DeclPos:    t.GetDeclarationPos(),
+				DeclDoc:    t.StructDoc(),
+				Directives: directives,
+				Package:    t.GetPackage(),
+				PkgPath:    pkgPath,
+				Exported:   t.exported,
+			})
+		}
+
+		for _, finding := range entry.findings {
+			stored := cachedFinding{ValidationError: *finding, Wraps: finding.err != nil}
+
+			if stored.Wraps {
+				stored.Err = finding.err.Error()
+			}
+
+			cached.Findings = append(cached.Findings, stored)
+		}
+
+		file.Files[fileName] = cached
+	}
+
+	buf := &bytes.Buffer{}
+
+	if err := gob.NewEncoder(buf).Encode(file); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(fc.dir, 0755); err != nil {
+		return err
+	}
+
+	return writeFileAtomic(filepath.Join(fc.dir, cacheFileName), buf.Bytes())
+}
+
+// fingerprint identifies the settings which change what is collected from a file.
+func (col *collector) fingerprint() string {
+	set := func(m map[string]bool) string {
+		if m == nil {
+			return "<nil>"
+		}
+
+		keys := make([]string, 0, len(m))
+
+		for key := range m {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		return strings.Join(keys, ",")
+	}
+
+	//The struct names of a file depend on the other packages parsed along with it
+	qualifiers := make([]string, 0, len(col.qualifiers))
+
+	for dir, qualifier := range col.qualifiers {
+		qualifiers = append(qualifiers, dir+"="+qualifier)
+	}
+
+	sort.Strings(qualifiers)
+	messages := make([]string, 0, len(col.messages))
+
+	for kind, tmpl := range col.messages {
+		messages = append(messages, string(kind)+"="+tmpl.Root.String())
+	}
+
+	sort.Strings(messages)
+
+	return strings.Join([]string{
+		set(col.keys),
+		set(col.knownTags),
+		set(col.allowedTags),
+		fmt.Sprint(col.checkDuplicateKeys),
+		fmt.Sprint(col.checkSyntax),
+		strings.Join(col.excludeFields, ","),
+		set(col.skipUnexported),
+		strings.Join(col.requiredTags, ","),
+		fmt.Sprint(col.includeLocalStructs),
+		strings.Join(qualifiers, ","),
+		col.source,
+		strings.Join(messages, ","),
+	}, "\x00")
+}
@@ -0,0 +1,185 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_testMemoryCache(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", `package models
+
+type Customer struct {
+	ID   int    `+"`"+`db:"id"`+"`"+`
+	Name string `+"`"+`db:"id"`+"`"+`
+}
+`)
+	createFile("order.go", `package models
+
+type Order struct {
+	ID int `+"`"+`db:"id" db:"order_id"`+"`"+`
+}
+`)
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("db")
+	m.SetMemoryCache(true)
+
+	parsed := m.Run()
+
+	r.Len(parsed, 2)
+	r.Equal(0, m.Stats().FilesCached)
+
+	cached := m.Run()
+
+	r.Equal(2, m.Stats().FilesCached)
+	r.Equal(2, m.Stats().FilesParsed)
+	r.Equal(parsed, cached)
+
+	//A changed file is parsed again
+	createFile("customer.go", `package models
+
+type Customer struct {
+	ID   int    `+"`"+`db:"id"`+"`"+`
+	Name string `+"`"+`db:"name"`+"`"+`
+}
+`)
+	later := time.Now().Add(time.Minute)
+	r.NoError(os.Chtimes(filepath.Join("models", "customer.go"), later, later))
+
+	r.Len(m.Run(), 1)
+	r.Equal(1, m.Stats().FilesCached)
+
+	//A removed file is evicted
+	r.NoError(os.Remove(filepath.Join("models", "order.go")))
+	r.Empty(m.Run())
+	r.Len(m.cache.entries, 1)
+
+	//Settings changing what is collected drop the cache
+	m.RequireTag("json")
+	r.Len(m.Run(), 2)
+	r.Equal(0, m.Stats().FilesCached)
+}
+
+func Test_testDiskCache(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", `package models
+
+type Customer struct {
+	ID    int    `+"`"+`db:"id" json:"id"`+"`"+`
+	Name  string `+"`"+`db:"id"`+"`"+`
+	Email string `+"`"+`db:"email" jsn:"email"`+"`"+`
+}
+`)
+	defer os.RemoveAll("./models")
+
+	dir := t.TempDir()
+	newValidator := func() Validator {
+		m := NewValidator(modelsPath)
+		m.AddDefaultProcessors("db")
+		m.SetKnownTags("db", "json")
+		m.SetCache(dir)
+
+		return m
+	}
+
+	m := newValidator()
+	parsed, err := m.Validate()
+
+	r.NoError(err)
+	r.Len(parsed.Findings, 2)
+	r.FileExists(filepath.Join(dir, cacheFileName))
+
+	tags := m.TagsFor("Customer")
+
+	//Another process reads the cache file
+	m = newValidator()
+	cached, err := m.Validate()
+
+	r.NoError(err)
+	r.Equal(1, m.Stats().FilesCached)
+	r.Equal(parsed.Findings, cached.Findings)
+
+	for i, tag := range m.TagsFor("Customer") {
+		r.Equal(tags[i].GetValue(), tag.GetValue())
+		r.Equal(tags[i].GetPosition(), tag.GetPosition())
+		r.Equal(tags[i].GetDeclarationPos(), tag.GetDeclarationPos())
+		r.True(tag.IsExported())
+
+		start, end := tag.GetValuePos()
+		expectedStart, expectedEnd := tags[i].GetValuePos()
+		r.Equal(expectedStart, start)
+		r.Equal(expectedEnd, end)
+	}
+
+	//A corrupt cache file is ignored
+	r.NoError(os.WriteFile(filepath.Join(dir, cacheFileName), []byte("corrupt"), 0644))
+
+	m = newValidator()
+	result, err := m.Validate()
+
+	r.NoError(err)
+	r.Equal(0, m.Stats().FilesCached)
+	r.Equal(parsed.Findings, result.Findings)
+}
+
+func Test_testDiskCacheWrappedErrors(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", "package models\n\ntype Customer struct {\n"+
+		"ID int `db:\"id\" db:\"ident\"`\n"+
+		"Name string `db:\"name\"`\n"+
+		"}\n")
+	defer os.RemoveAll("./models")
+
+	dir := t.TempDir()
+	newValidator := func() Validator {
+		m := NewValidator(modelsPath)
+		m.AddDefaultProcessors("db")
+		m.RequireTag("json")
+		m.SetCache(dir)
+
+		return m
+	}
+
+	m := newValidator()
+	parsed, err := m.Validate()
+
+	r.NoError(err)
+	r.Len(parsed.Findings, 3)
+
+	//The cached findings wrap the same errors as the parsed ones
+	m = newValidator()
+	cached, err := m.Validate()
+
+	r.NoError(err)
+	r.Equal(1, m.Stats().FilesCached)
+	r.Equal(parsed.Findings, cached.Findings)
+
+	for i, finding := range cached.Findings {
+		r.NotNil(errors.Unwrap(finding), finding.Message)
+		r.Equal(errors.Unwrap(parsed.Findings[i]).Error(), errors.Unwrap(finding).Error())
+	}
+
+	//A file whose findings wrap more than a message isn't cached, errors.Is would tell them apart
+	fc := &fileCache{}
+	col := &collector{logger: slog.New(discardHandler{})}
+	info, err := os.Stat(filepath.Join("models", "customer.go"))
+	r.NoError(err)
+
+	fc.put(col, "customer.go", info, nil, []error{&ValidationError{Message: "timed out", err: fmt.Errorf("check: %w", ErrTimeout)}}, 0)
+	r.Empty(fc.entries)
+
+	fc.put(col, "customer.go", info, nil, []error{&ValidationError{Message: "bad", err: errors.New("bad")}}, 0)
+	r.Len(fc.entries, 1)
+}
@@ -0,0 +1,141 @@
+package validator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// DefaultValueCharset is the character class the default processors check tag values against.
+const DefaultValueCharset = "a-z0-9_, "
+
+var defaultCharset = mustCharset(DefaultValueCharset)
+
+// regexRule reports a tag value matching its expression.
+// A rule with a suggest function hints at a valid value, one with a fix function can replace the value.
+type regexRule struct {
+	kind    MessageKind
+	rexpr   *regexp.Regexp
+	suggest func(value string) string
+	fix     func(value string) string
+}
+
+// charset is a set of characters allowed in tag values, given as the content of a regexp character class.
+// Values must end on a letter or a digit of the set, so both of its rules derive from the same class.
+type charset struct {
+	class string
+	valid *regexp.Regexp
+	rules []regexRule
+}
+
+func newCharset(class string) (*charset, error) {
+	if strings.HasPrefix(class, "[") && strings.HasSuffix(class, "]") {
+		class = class[1 : len(class)-1]
+	}
+
+	if len(class) == 0 || strings.HasPrefix(class, "^") {
+		return nil, fmt.Errorf("invalid charset [%v]", class)
+	}
+
+	valid, err := regexp.Compile("^[" + class + "]$")
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid charset [%v]: %v", class, err)
+	}
+
+	c := &charset{class: class, valid: valid}
+
+	c.rules = []regexRule{
+		//allowed symbols in a tag
+		{
+			kind:    MessageInvalidSymbols,
+			rexpr:   regexp.MustCompile("[^" + class + "]+"),
+			suggest: c.suggest,
+		},
+		//allowed symbols of the end of a tag
+		{
+			kind:  MessageTrailingChar,
+			rexpr: regexp.MustCompile(`(?:[^` + class + `]|[^\pL\pN])$`),
+			fix: func(value string) string {
+				return strings.TrimRightFunc(value, func(r rune) bool {
+					return !c.isValidEnd(r)
+				})
+			},
+		},
+	}
+
+	return c, nil
+}
+
+func mustCharset(class string) *charset {
+	c, err := newCharset(class)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+func (c *charset) isValid(r rune) bool {
+	return c.valid.MatchString(string(r))
+}
+
+func (c *charset) isValidEnd(r rune) bool {
+	return c.isValid(r) && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+// suggest replaces every run of invalid characters with an underscore.
+// Letters which are only invalid because of their case are converted instead.
+func (c *charset) suggest(value string) string {
+	suggestion := strings.Builder{}
+	invalidRun := false
+
+	for _, r := range value {
+		if !c.isValid(r) && c.isValid(unicode.ToLower(r)) {
+			r = unicode.ToLower(r)
+		}
+
+		if c.isValid(r) {
+			suggestion.WriteRune(r)
+			invalidRun = false
+			continue
+		}
+
+		if !invalidRun {
+			suggestion.WriteRune('_')
+			invalidRun = true
+		}
+	}
+
+	return suggestion.String()
+}
+
+// SetAllowedValueCharset overrides the characters the default processors allow in the values of the given tag.
+// The pattern is the content of a regexp character class, e.g. `a-zA-Z0-9_.`, which must compile.
+// Values must end on a letter or a digit of the set.
+func (v *Validator) SetAllowedValueCharset(tag string, pattern string) error {
+	c, err := newCharset(pattern)
+
+	if err != nil {
+		return err
+	}
+
+	v.charsets[tag] = c
+
+	return nil
+}
+
+// charsetFor returns the charset in effect for the given tag.
+func (v *Validator) charsetFor(tag string) *charset {
+	if c, exists := v.charsets[tag]; exists {
+		return c
+	}
+
+	if c, exists := v.charsets[AllTags]; exists {
+		return c
+	}
+
+	return defaultCharset
+}
@@ -0,0 +1,354 @@
+// Command tagvalidator validates the struct tags of the models found at the given path.
+//
+// Usage:
+//
+//	tagvalidator [run|list|fix] [flags] path [models...]
+//
+// The run command validates the tags with the default processors, list prints the collected tags without validating them
+// and fix applies the replacements suggested by the processors to the model files.
+//
+// With -out the report is written to a file, e.g. from a go:generate directive, and -check exits with status 3 if the file is out of date:
+//
+//	//go:generate tagvalidator -out tagreport.txt ./models
+//
+// With -files only the given files are validated, e.g. in a pre-commit hook:
+//
+//	git diff --cached --name-only --diff-filter=d -- '*.go' | tagvalidator -files - path
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	validator "github.com/petar-dambovaliev/struct-tag-validator"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	command := "run"
+
+	if len(args) > 0 && (args[0] == "run" || args[0] == "list" || args[0] == "fix" || args[0] == "diff") {
+		command = args[0]
+		args = args[1:]
+	}
+
+	flags := flag.NewFlagSet("tagvalidator "+command, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	tags := flags.String("tags", "", "comma separated tag names to validate, all tags if empty")
+	allowDuplicates := flags.Bool("allow-duplicates", false, "skip the duplicate values check")
+	dryRun := flags.Bool("dry-run", false, "fix: print a unified diff instead of changing the files")
+	format := flags.String("format", "text", "run: output format, text, json, html or sarif")
+	configPath := flags.String("config", "", "YAML or JSON config of the validator, the path argument may be left out if it has one and replaces its path otherwise")
+	out := flags.String("out", "", "run: write the report to this file instead of stdout, e.g. from a go:generate directive")
+	check := flags.Bool("check", false, "run: compare the report with the -out file instead of writing it, exit with status 3 if it is out of date")
+	files := flags.String("files", "", "run: comma separated Go files to validate instead of the whole path, - reads them from stdin one per line")
+	fullDuplicates := flags.Bool("full-duplicates", false, "run: look for the duplicate values of the -files in their whole packages")
+	failOn := flags.String("fail-on", "", "run: comma separated tag names whose errors fail the run, e.g. db,validate, the errors of all tags if empty")
+	all := flags.Bool("all", false, "diff: print the removed and unchanged findings as well as the added ones")
+	dialect := flags.String("dialect", "", "check the db tags against the identifier rules of an SQL dialect, sql, postgres, mysql or sqlite")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if command == "diff" {
+		if flags.NArg() != 2 {
+			fmt.Fprintf(stderr, "usage: tagvalidator diff [-all] [-format text|json] old.json new.json\n")
+			return 2
+		}
+
+		return diff(flags.Arg(0), flags.Arg(1), *all, *format, stdout, stderr)
+	}
+
+	if flags.NArg() == 0 && len(*configPath) == 0 && len(*files) == 0 {
+		fmt.Fprintf(stderr, "usage: tagvalidator [run|list|fix|diff] [flags] path [models...]\n")
+		flags.PrintDefaults()
+		return 2
+	}
+
+	path := flags.Arg(0)
+
+	//The files are resolved against the working directory
+	if len(path) == 0 && len(*files) > 0 {
+		path = "."
+	}
+
+	v := validator.NewValidator(path)
+
+	if len(*configPath) > 0 {
+		if err := loadConfig(&v, *configPath); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+
+		//The path argument beats the path of the config, like the other flags
+		if flags.NArg() > 0 {
+			v.SetPath(flags.Arg(0))
+		}
+	}
+
+	if *allowDuplicates {
+		v.SetAllowDuplicates(true)
+	}
+
+	models := []string{}
+
+	if flags.NArg() > 1 {
+		models = flags.Args()[1:]
+	}
+
+	tagNames := []string{}
+
+	if len(*tags) > 0 {
+		tagNames = strings.Split(*tags, ",")
+	}
+
+	if command == "list" {
+		for _, name := range tagNames {
+			v.AddProcessor(name, func(*validator.Tag) []error { return nil })
+		}
+
+		return list(&v, models, stdout, stderr)
+	}
+
+	//The config names the processors itself
+	if len(*configPath) == 0 || len(tagNames) > 0 {
+		v.AddDefaultProcessors(tagNames...)
+	}
+
+	if len(*dialect) > 0 {
+		processor, err := validator.NewDialectProcessor(validator.Dialect(*dialect))
+
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+
+		v.AddProcessorNamed("db", "dialect", processor)
+	}
+
+	if command == "fix" {
+		return fix(&v, *dryRun, models, stdout, stderr)
+	}
+
+	if len(*out) > 0 {
+		return writeReportFile(&v, *out, validator.Format(*format), *check, stderr)
+	}
+
+	var report *validator.Report
+
+	if len(*files) > 0 {
+		paths, err := fileList(*files, stdin)
+
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+
+		//Nothing changed, e.g. in a pre-commit hook
+		if len(paths) == 0 {
+			return 0
+		}
+
+		v.SetFullDuplicates(*fullDuplicates)
+		report = validator.NewReport(v.RunFiles(paths...))
+		stats := v.Stats()
+		report.Stats = &stats
+		report.Files = v.FileDiagnostics()
+	} else {
+		report = v.RunReport(models...)
+	}
+
+	if err := report.Write(stdout, validator.Format(*format)); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	failTags := []string{}
+
+	if len(*failOn) > 0 {
+		failTags = strings.Split(*failOn, ",")
+	}
+
+	//Warnings and the findings of other tags are reported, but don't fail the run
+	result := validator.RunResult{Findings: report.Findings}
+
+	if result.FailedFor(failTags...) || len(report.Errors) > 0 {
+		return 1
+	}
+
+	return 0
+}
+
+// fileList returns the files of the -files flag, read from stdin one per line if it is -.
+func fileList(files string, stdin io.Reader) ([]string, error) {
+	if files != "-" {
+		return strings.Split(files, ","), nil
+	}
+
+	paths := []string{}
+	scanner := bufio.NewScanner(stdin)
+
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); len(line) > 0 {
+			paths = append(paths, line)
+		}
+	}
+
+	return paths, scanner.Err()
+}
+
+// writeReportFile writes the report to a file, the findings don't change the exit status, only a stale file in check mode does.
+func writeReportFile(v *validator.Validator, path string, format validator.Format, check bool, stderr io.Writer) int {
+	var err error
+
+	if check {
+		err = v.CheckReportFile(path, format)
+	} else {
+		err = v.WriteReportFile(path, format)
+	}
+
+	if errors.Is(err, validator.ErrStaleReport) {
+		fmt.Fprintln(stderr, err)
+		return 3
+	}
+
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	return 0
+}
+
+func loadConfig(v *validator.Validator, path string) error {
+	f, err := os.Open(path)
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	if err := v.LoadConfig(f); err != nil {
+		return fmt.Errorf("%v: %w", path, err)
+	}
+
+	return nil
+}
+
+func list(v *validator.Validator, models []string, stdout, stderr io.Writer) int {
+	tags, err := v.ListTags(models...)
+
+	if tags == nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	structNames := make([]string, 0, len(tags))
+
+	for structName := range tags {
+		structNames = append(structNames, structName)
+	}
+
+	sort.Strings(structNames)
+
+	for _, structName := range structNames {
+		fmt.Fprintln(stdout, structName)
+
+		for _, tag := range tags[structName] {
+			fmt.Fprintf(stdout, "\t%v:%q\n", tag.GetName(), tag.GetValue())
+		}
+	}
+
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	return 0
+}
+
+func fix(v *validator.Validator, dryRun bool, models []string, stdout, stderr io.Writer) int {
+	var changed int
+	var err error
+
+	if dryRun {
+		changed, err = v.FixDryRun(stdout, models...)
+	} else {
+		changed, err = v.Fix(models...)
+	}
+
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	if !dryRun {
+		fmt.Fprintf(stdout, "%v files changed\n", changed)
+	}
+
+	return 0
+}
+
+// diff compares two JSON reports and prints the added findings, exiting with status 1 if there are some.
+func diff(oldPath, newPath string, all bool, format string, stdout, stderr io.Writer) int {
+	reports := make([]*validator.Report, 0, 2)
+
+	for _, path := range []string{oldPath, newPath} {
+		f, err := os.Open(path)
+
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+
+		report, err := validator.ReadJSONReport(f)
+		f.Close()
+
+		if err != nil {
+			fmt.Fprintf(stderr, "%v: %v\n", path, err)
+			return 2
+		}
+
+		reports = append(reports, report)
+	}
+
+	d := validator.Diff(reports[0], reports[1])
+	printed := d
+
+	if !all {
+		printed = d.Additions()
+	}
+
+	var err error
+
+	switch validator.Format(format) {
+	case validator.FormatJSON:
+		err = printed.WriteJSON(stdout)
+	case validator.FormatText:
+		err = printed.WriteText(stdout)
+	default:
+		err = fmt.Errorf("unsupported diff format %v, expected text or json", format)
+	}
+
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	if d.HasAdditions() {
+		return 1
+	}
+
+	return 0
+}
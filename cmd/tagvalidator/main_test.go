@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_testRunConfigPath(t *testing.T) {
+	r := require.New(t)
+
+	dir := t.TempDir()
+	configured := filepath.Join(dir, "configured")
+	given := filepath.Join(dir, "given")
+	config := filepath.Join(dir, "tagvalidator.yaml")
+
+	r.NoError(os.Mkdir(configured, 0755))
+	r.NoError(os.Mkdir(given, 0755))
+	r.NoError(os.WriteFile(filepath.Join(configured, "customer.go"), []byte("package configured\n\ntype Customer struct {\n\tID int `db:\"ID\"`\n}\n"), 0644))
+	r.NoError(os.WriteFile(filepath.Join(given, "order.go"), []byte("package given\n\ntype Order struct {\n\tID int `db:\"Id\"`\n}\n"), 0644))
+	r.NoError(os.WriteFile(config, []byte("path: "+configured+"\ntags: [db]\n"), 0644))
+
+	//The path argument beats the path of the config
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+	r.Equal(1, run([]string{"-config", config, given}, nil, stdout, stderr), stderr.String())
+	r.Contains(stdout.String(), "Order.db.Id")
+	r.NotContains(stdout.String(), "Customer")
+
+	//Without it, the config's is validated
+	stdout.Reset()
+
+	r.Equal(1, run([]string{"-config", config}, nil, stdout, stderr), stderr.String())
+	r.Contains(stdout.String(), "Customer.db.ID")
+	r.NotContains(stdout.String(), "Order")
+}
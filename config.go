@@ -0,0 +1,471 @@
+package validator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProcessorFactory adds the processors of a name given in a config for the given tags.
+// The tags are `*` if the config names none.
+// v is a copy which replaces the validator once the whole config is applied, so it shouldn't be kept by the processors.
+type ProcessorFactory func(v *Validator, tags []string, args ProcessorArgs) error
+
+// ProcessorArgs holds the arguments given to a processor in a config.
+type ProcessorArgs struct {
+	node *yaml.Node
+}
+
+// Decode decodes the arguments into the struct v points to, its fields are named by their yaml tags.
+// Arguments the struct doesn't have are reported along with their line.
+func (a ProcessorArgs) Decode(v interface{}) error {
+	if a.node == nil || a.node.Kind == 0 {
+		return nil
+	}
+
+	if a.node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %v: the args must be a mapping", a.node.Line)
+	}
+
+	known := map[string]bool{}
+	t := reflect.TypeOf(v).Elem()
+
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+
+		if len(name) == 0 {
+			name = strings.ToLower(t.Field(i).Name)
+		}
+
+		known[name] = true
+	}
+
+	for i := 0; i+1 < len(a.node.Content); i += 2 {
+		if key := a.node.Content[i]; !known[key.Value] {
+			return fmt.Errorf("line %v: unknown argument %v", key.Line, key.Value)
+		}
+	}
+
+	return a.node.Decode(v)
+}
+
+// processorRegistry holds the processors a config can name.
+var processorRegistry = map[string]ProcessorFactory{
+	"default": func(v *Validator, tags []string, args ProcessorArgs) error {
+		if err := args.Decode(&struct{}{}); err != nil {
+			return err
+		}
+
+		v.AddDefaultProcessors(tags...)
+
+		return nil
+	},
+	"json": func(v *Validator, tags []string, args ProcessorArgs) error {
+		if err := args.Decode(&struct{}{}); err != nil {
+			return err
+		}
+
+		v.AddJSONProcessors()
+
+		return nil
+	},
+	"sqlx": func(v *Validator, tags []string, args ProcessorArgs) error {
+		sqlx := struct {
+			Options []string `yaml:"options"`
+		}{}
+
+		if err := args.Decode(&sqlx); err != nil {
+			return err
+		}
+
+		v.AddSQLXProcessors(sqlx.Options...)
+
+		return nil
+	},
+	"naming": func(v *Validator, tags []string, args ProcessorArgs) error {
+		naming := struct {
+			Style    string   `yaml:"style"`
+			Acronyms []string `yaml:"acronyms"`
+		}{}
+
+		if err := args.Decode(&naming); err != nil {
+			return err
+		}
+
+		if naming.Style != "snake" {
+			return fmt.Errorf("Unknown naming style %v, expected snake", naming.Style)
+		}
+
+		for _, tag := range tags {
+			v.AddProcessorNamed(tag, "naming", NewFieldNameConsistencyProcessor(SnakeCaseWithAcronyms(naming.Acronyms...)))
+		}
+
+		return nil
+	},
+	"max-length": func(v *Validator, tags []string, args ProcessorArgs) error {
+		maxLength := struct {
+			Max int `yaml:"max"`
+		}{}
+
+		if err := args.Decode(&maxLength); err != nil {
+			return err
+		}
+
+		if maxLength.Max < 1 {
+			return errors.New("The max length must be at least 1")
+		}
+
+		for _, tag := range tags {
+			v.AddProcessorNamed(tag, "max-length", NewMaxLengthProcessor(maxLength.Max))
+		}
+
+		return nil
+	},
+	"reserved-words": func(v *Validator, tags []string, args ProcessorArgs) error {
+		reserved := struct {
+			Dialect string `yaml:"dialect"`
+		}{}
+
+		if err := args.Decode(&reserved); err != nil {
+			return err
+		}
+
+		processor, err := NewReservedWordsProcessor(reserved.Dialect)
+
+		if err != nil {
+			return err
+		}
+
+		for _, tag := range tags {
+			v.AddProcessorNamed(tag, "reserved-words", processor)
+		}
+
+		return nil
+	},
+	"conventions": func(v *Validator, tags []string, args ProcessorArgs) error {
+		conventions := struct {
+			Required []string `yaml:"required"`
+			Unique   []string `yaml:"unique"`
+			Exclude  []string `yaml:"exclude"`
+		}{}
+
+		if err := args.Decode(&conventions); err != nil {
+			return err
+		}
+
+		if len(conventions.Required) == 0 && len(conventions.Unique) == 0 {
+			return errors.New("The conventions need required or unique values")
+		}
+
+		for _, tag := range tags {
+			v.AddStructProcessorNamed(tag, "conventions", NewConventionProcessor(conventions.Required, conventions.Unique, conventions.Exclude...))
+		}
+
+		return nil
+	},
+	"order": func(v *Validator, tags []string, args ProcessorArgs) error {
+		order := struct {
+			First []string `yaml:"first"`
+			Last  []string `yaml:"last"`
+		}{}
+
+		if err := args.Decode(&order); err != nil {
+			return err
+		}
+
+		if len(order.First) == 0 && len(order.Last) == 0 {
+			return errors.New("The order needs first or last values")
+		}
+
+		for _, tag := range tags {
+			v.AddStructProcessorNamed(tag, "order", NewOrderProcessor(order.First, order.Last))
+		}
+
+		return nil
+	},
+	"enum": func(v *Validator, tags []string, args ProcessorArgs) error {
+		enum := struct {
+			Values          []string `yaml:"values"`
+			CaseInsensitive bool     `yaml:"case_insensitive"`
+		}{}
+
+		if err := args.Decode(&enum); err != nil {
+			return err
+		}
+
+		newProcessor := NewEnumProcessor
+
+		if enum.CaseInsensitive {
+			newProcessor = NewEnumProcessorFold
+		}
+
+		processor, err := newProcessor(enum.Values...)
+
+		if err != nil {
+			return err
+		}
+
+		for _, tag := range tags {
+			v.AddProcessorNamed(tag, "enum", processor)
+		}
+
+		return nil
+	},
+	"dialect": func(v *Validator, tags []string, args ProcessorArgs) error {
+		dialect := struct {
+			Dialect string `yaml:"dialect"`
+		}{}
+
+		if err := args.Decode(&dialect); err != nil {
+			return err
+		}
+
+		processor, err := NewDialectProcessor(Dialect(dialect.Dialect))
+
+		if err != nil {
+			return err
+		}
+
+		for _, tag := range tags {
+			v.AddProcessorNamed(tag, "dialect", processor)
+		}
+
+		return nil
+	},
+	"struct-tag-syntax": func(v *Validator, tags []string, args ProcessorArgs) error {
+		if err := args.Decode(&struct{}{}); err != nil {
+			return err
+		}
+
+		//The whole literal is checked, whatever the tags
+		v.AddStructTagSyntaxProcessor()
+
+		return nil
+	},
+	"mirror-tags": func(v *Validator, tags []string, args ProcessorArgs) error {
+		mirror := struct {
+			Pairs map[string]string `yaml:"pairs"`
+		}{}
+
+		if err := args.Decode(&mirror); err != nil {
+			return err
+		}
+
+		if len(mirror.Pairs) == 0 {
+			return errors.New("The mirror needs pairs of struct names")
+		}
+
+		for _, tag := range tags {
+			if tag == AllTags {
+				return errors.New("The mirror needs the tags to compare")
+			}
+
+			v.AddPackageProcessorNamed("mirror-tags", NewMirrorTagsProcessor(tag, mirror.Pairs))
+		}
+
+		return nil
+	},
+}
+
+// RegisterProcessor makes a processor available to configs under the given name, replacing a processor of the same name.
+// It isn't safe for concurrent use and is meant to be called from init functions.
+func RegisterProcessor(name string, factory ProcessorFactory) {
+	processorRegistry[name] = factory
+}
+
+// config is the document read by LoadConfig.
+type config struct {
+	Path            string            `yaml:"path"`
+	Paths           []string          `yaml:"paths"`
+	Tags            []string          `yaml:"tags"`
+	Processors      []processorConfig `yaml:"processors"`
+	AllowDuplicates []string          `yaml:"allow_duplicates"`
+	AllowEmpty      []string          `yaml:"allow_empty"`
+	RequireTags     []string          `yaml:"require_tags"`
+	IncludeStructs  []string          `yaml:"include_structs"`
+	ExcludeStructs  []string          `yaml:"exclude_structs"`
+	ExcludeFields   []string          `yaml:"exclude_fields"`
+	SkipUnexported  []string          `yaml:"skip_unexported"`
+	Warnings        []string          `yaml:"warnings"`
+	Recursive       bool              `yaml:"recursive"`
+}
+
+// processorConfig names a processor of the registry, the nodes keep their lines for the errors.
+type processorConfig struct {
+	Name yaml.Node `yaml:"name"`
+	Tags []string  `yaml:"tags"`
+	Args yaml.Node `yaml:"args"`
+}
+
+// LoadConfig reads a YAML or JSON document and applies it to the validator, e.g.
+//
+//	path: ./models
+//	tags: [db]                 # the default processors are added for these tags
+//	processors:
+//	  - name: max-length       # one of default, json, sqlx, naming, max-length, reserved-words, dialect, conventions, order, enum, mirror-tags, struct-tag-syntax or a registered one
+//	    tags: [db]
+//	    args: {max: 63}
+//	allow_duplicates: [json]   # tags whose values may repeat, "*" for all
+//	exclude_fields: [XXX_*]
+//
+// Further keys are paths, allow_empty, require_tags, include_structs, exclude_structs, skip_unexported, warnings and recursive.
+// The findings of the tags under warnings, "*" for all, are reported as warnings, see SetSeverity. A lone * is a YAML alias, so it is quoted.
+// A JSON document is read as YAML, the JSON escapes YAML lacks, \/ and surrogate pairs, are replaced first.
+// Unknown keys, processors and arguments are reported along with their line, the validator is only changed if the whole config applies.
+func (v *Validator) LoadConfig(r io.Reader) error {
+	data, err := io.ReadAll(r)
+
+	if err != nil {
+		return err
+	}
+
+	cfg := config{}
+	decoder := yaml.NewDecoder(bytes.NewReader(replaceJSONEscapes(data)))
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	for _, p := range cfg.Processors {
+		if _, exists := processorRegistry[p.Name.Value]; !exists {
+			return fmt.Errorf("line %v: unknown processor %v", p.Name.Line, p.Name.Value)
+		}
+	}
+
+	//The config is applied to a copy, which replaces the validator once nothing failed
+	staged := v.snapshot()
+
+	if err := staged.applyConfig(cfg); err != nil {
+		return err
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	//Runs which finished meanwhile keep their state
+	current := *v
+	*v = *staged
+	v.setRunState(&current)
+
+	return nil
+}
+
+// applyConfig applies a decoded config to the validator.
+func (v *Validator) applyConfig(cfg config) error {
+	if len(cfg.Path) > 0 {
+		v.setPath(cfg.Path)
+	}
+
+	v.AddPaths(cfg.Paths...)
+
+	if len(cfg.Tags) > 0 {
+		v.AddDefaultProcessors(cfg.Tags...)
+	}
+
+	for _, p := range cfg.Processors {
+		factory := processorRegistry[p.Name.Value]
+		tags := p.Tags
+
+		if len(tags) == 0 {
+			tags = []string{AllTags}
+		}
+
+		if err := factory(v, tags, ProcessorArgs{node: &p.Args}); err != nil {
+			return fmt.Errorf("line %v: processor %v: %w", p.Name.Line, p.Name.Value, err)
+		}
+	}
+
+	for _, tag := range cfg.AllowDuplicates {
+		if tag == AllTags {
+			v.SetAllowDuplicates(true)
+		}
+	}
+
+	v.AllowDuplicateValues(cfg.AllowDuplicates...)
+	v.AllowEmptyValue(cfg.AllowEmpty...)
+	v.RequireTag(cfg.RequireTags...)
+	v.IncludeStructs(cfg.IncludeStructs...)
+	v.ExcludeStructs(cfg.ExcludeStructs...)
+	v.ExcludeFields(cfg.ExcludeFields...)
+	v.SetSkipUnexported(cfg.SkipUnexported...)
+
+	if len(cfg.Warnings) > 0 {
+		v.SetSeverity(SeverityWarning, cfg.Warnings...)
+	}
+
+	if cfg.Recursive {
+		v.SetRecursive(true)
+	}
+
+	return nil
+}
+
+// replaceJSONEscapes replaces the escapes of the strings of a JSON document which YAML doesn't have, \/ and the surrogate pairs of \u escapes.
+// Other documents are returned as they are, the lines don't change.
+func replaceJSONEscapes(data []byte) []byte {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) == 0 || trimmed[0] != '{' {
+		return data
+	}
+
+	out := make([]byte, 0, len(data))
+	inString := false
+
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+
+		if c == '"' {
+			inString = !inString
+		}
+
+		if !inString || c != '\\' || i+1 == len(data) {
+			out = append(out, c)
+			continue
+		}
+
+		//The other escapes are copied along with their backslash, so an escaped quote doesn't end the string
+		if r, ok := surrogatePair(data[i:]); ok {
+			out = append(out, fmt.Sprintf("\\U%08X", r)...)
+			i += 11
+		} else if data[i+1] == '/' {
+			out = append(out, '/')
+			i++
+		} else {
+			out = append(out, c, data[i+1])
+			i++
+		}
+	}
+
+	return out
+}
+
+// surrogatePair decodes the rune of a surrogate pair written as two \u escapes at the start of data.
+func surrogatePair(data []byte) (rune, bool) {
+	if len(data) < 12 || data[1] != 'u' || data[6] != '\\' || data[7] != 'u' {
+		return 0, false
+	}
+
+	high, err := strconv.ParseUint(string(data[2:6]), 16, 16)
+
+	if err != nil {
+		return 0, false
+	}
+
+	low, err := strconv.ParseUint(string(data[8:12]), 16, 16)
+
+	if err != nil {
+		return 0, false
+	}
+
+	r := utf16.DecodeRune(rune(high), rune(low))
+
+	return r, r != utf8.RuneError
+}
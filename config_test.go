@@ -0,0 +1,160 @@
+package validator
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var configModel = `package models
+
+type Customer struct {
+	ID               int    ` + "`" + `db:"id" json:"id"` + "`" + `
+	Order            string ` + "`" + `db:"order" json:"id"` + "`" + `
+	CreatedAt        string ` + "`" + `db:"created"` + "`" + `
+	Description      string ` + "`" + `db:"a_very_long_column_name"` + "`" + `
+	XXX_unrecognized []byte ` + "`" + `db:"id"` + "`" + `
+}
+
+type AuditCustomer struct {
+	ID int ` + "`" + `db:"Select"` + "`" + `
+}
+`
+
+func Test_testLoadConfig(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", configModel)
+	defer os.RemoveAll("./models")
+
+	yamlConfig := `
+path: ` + modelsPath + `
+tags: [db, json]
+processors:
+  - name: max-length
+    tags: [db]
+    args:
+      max: 16
+  - name: reserved-words
+    tags: [db]
+    args: {dialect: postgres}
+  - name: naming
+    tags: [db]
+    args: {style: snake}
+allow_duplicates: [json]
+exclude_fields: [XXX_*]
+exclude_structs: [Audit*]
+`
+	jsonConfig := `{
+	"path": "` + modelsPath + `",
+	"tags": ["db", "json"],
+	"processors": [
+		{"name": "max-length", "tags": ["db"], "args": {"max": 16}},
+		{"name": "reserved-words", "tags": ["db"], "args": {"dialect": "postgres"}},
+		{"name": "naming", "tags": ["db"], "args": {"style": "snake"}}
+	],
+	"allow_duplicates": ["json"],
+	"exclude_fields": ["XXX_*"],
+	"exclude_structs": ["Audit*"]
+}`
+
+	for _, cfg := range []string{yamlConfig, jsonConfig} {
+		m := NewValidator("unused")
+
+		r.NoError(m.LoadConfig(strings.NewReader(cfg)))
+
+		messages := []string{}
+
+		for _, err := range m.Run() {
+			messages = append(messages, err.Error())
+		}
+
+		r.ElementsMatch([]string{
+			"Tag value order in Customer.db is a reserved word in postgres",
+			"Tag value created does not match the field name CreatedAt in Customer.db, expected created_at (suggested: created_at)",
+			"Tag value a_very_long_column_name in Customer.db is 23 characters long, the maximum is 16",
+			"Tag value a_very_long_column_name does not match the field name Description in Customer.db, expected description (suggested: description)",
+		}, messages)
+	}
+}
+
+func Test_testLoadConfigErrors(t *testing.T) {
+	r := require.New(t)
+
+	cases := map[string]string{
+		"path: models\nexclude: [a]\n":                                         "line 2: field exclude not found",
+		"processors:\n  - name: max-length\n  - name: min-length\n":            "line 3: unknown processor min-length",
+		"processors:\n  - name: max-length\n    args: {maximum: 3}\n":          "line 3: unknown argument maximum",
+		"processors:\n  - name: max-length\n    color: red\n":                  "line 3: field color not found",
+		"processors:\n  - name: reserved-words\n    args: {dialect: oracle}\n": "line 2: processor reserved-words: Unknown dialect oracle, expected one of mysql, postgres, sql, sqlite",
+		"path: models\ntags: [db]\npath: other\n":                              "line 3: mapping key \"path\" already defined at line 1",
+		"processors:\n  - name: max-length\n    args: {max: 3, max: 4}\n":      "line 3: mapping key \"max\" already defined at line 3",
+		`{"path": "models",` + "\n" + `"path": "other"}`:                       "line 2: mapping key \"path\" already defined at line 1",
+		"recursive: maybe\n": "line 1: cannot unmarshal !!str `maybe` into bool",
+	}
+
+	for cfg, expected := range cases {
+		m := NewValidator(modelsPath)
+		err := m.LoadConfig(strings.NewReader(cfg))
+
+		r.Error(err, cfg)
+		r.Contains(err.Error(), expected)
+	}
+
+	m := NewValidator(modelsPath)
+	r.NoError(m.LoadConfig(strings.NewReader("")))
+}
+
+func Test_testLoadConfigUnchangedOnError(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", configModel)
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("json")
+
+	//The path, tags and first processor are valid, the dialect isn't
+	err := m.LoadConfig(strings.NewReader(`
+path: ./missing
+tags: [db]
+exclude_structs: [Audit*]
+processors:
+  - name: max-length
+    tags: [db]
+    args: {max: 3}
+  - name: dialect
+    tags: [db]
+    args: {dialect: oracle}
+`))
+	r.Error(err)
+	r.Contains(err.Error(), "line 9: processor dialect")
+
+	result, err := m.Validate()
+	r.NoError(err)
+	r.Len(result.Findings, 1)
+	r.Equal("json", result.Findings[0].Tag)
+}
+
+func Test_testLoadConfigJSONEscapes(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", configModel)
+	defer os.RemoveAll("./models")
+
+	//Escaped slashes, quotes and a surrogate pair, which YAML doesn't have
+	m := NewValidator("unused")
+	err := m.LoadConfig(strings.NewReader(`{
+	"path": "` + strings.ReplaceAll(modelsPath, "/", `\/`) + `",
+	"exclude_structs": ["\u0041udit*", "\"quoted\\\"", "\ud83d\ude00"],
+	"processors": [{"name": "enum", "tags": ["db"], "args": {"values": ["id", "\ud83d\ude00", "a\/b"]}}]
+}`))
+	r.NoError(err)
+	r.Equal(modelsPath, m.path)
+	r.Equal([]string{"Audit*", `"quoted\"`, "\U0001F600"}, m.excludeStructs)
+
+	r.Equal([]byte(`{"a": "\U0001F600 \u0041 \\/ /"}`), replaceJSONEscapes([]byte(`{"a": "\ud83d\ude00 \u0041 \\/ \/"}`)))
+	r.Equal([]byte(`a: "\/"`), replaceJSONEscapes([]byte(`a: "\/"`)))
+}
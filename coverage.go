@@ -0,0 +1,120 @@
+package validator
+
+import (
+	"fmt"
+	"sort"
+)
+
+// UnusedTagsProcessor is the name the findings of SetReportUnusedTags and SetErrorOnUnusedTag are counted under in Summary.
+const UnusedTagsProcessor = "unused-tags"
+
+// TagCoverage is what the last run did for one tag key, see Validator.Coverage.
+// ProcessorsRun counts the tag processors run on the tags of the key and the struct processors run on structs having it.
+// Registered is false for keys only covered by processors added for all tags.
+type TagCoverage struct {
+	Tags          int
+	ProcessorsRun int
+	Registered    bool
+}
+
+// Coverage returns the coverage of the last run by tag key, for every key processors were added for
+// and, if some were added for all tags, every key they were run on. A key with no tags was found nowhere,
+// which a clean run can't tell apart from a key without problems, see SetErrorOnUnusedTag.
+func (v *Validator) Coverage() map[string]TagCoverage {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	coverage := make(map[string]TagCoverage, len(v.coverage))
+
+	for key, c := range v.coverage {
+		coverage[key] = c
+	}
+
+	return coverage
+}
+
+// SetErrorOnUnusedTag sets a flag if a tag key processors were added for, but which no struct has, is reported as an error.
+// Unlike the warnings of SetReportUnusedTags, it fails the run, e.g. on a typo in the tag name given to AddProcessor.
+func (v *Validator) SetErrorOnUnusedTag(errorOnUnusedTag bool) {
+	v.errorOnUnusedTag = errorOnUnusedTag
+}
+
+// unusedTagsSeverity returns the severity of the findings about unused tag keys, empty if they aren't reported.
+func (v *Validator) unusedTagsSeverity() Severity {
+	if v.errorOnUnusedTag {
+		return SeverityError
+	}
+
+	if v.reportUnusedTags {
+		return SeverityWarning
+	}
+
+	return ""
+}
+
+// tagCoverage counts the collected tags of the given processor tags, the processors run are counted while processing.
+func (v *Validator) tagCoverage(tags []string) map[string]TagCoverage {
+	coverage := map[string]TagCoverage{}
+	wildcard := false
+
+	for _, tag := range tags {
+		if tag == AllTags {
+			wildcard = true
+			continue
+		}
+
+		coverage[tag] = TagCoverage{Registered: true}
+	}
+
+	for _, fields := range v.tags {
+		for _, t := range fields {
+			c, exists := coverage[t.GetName()]
+
+			if !exists && !wildcard {
+				continue
+			}
+
+			c.Tags++
+			coverage[t.GetName()] = c
+		}
+	}
+
+	return coverage
+}
+
+// countProcessorsRun adds the processors run per tag key by the workers to the coverage.
+func (v *Validator) countProcessorsRun(counts []processCounts) {
+	for _, c := range counts {
+		for key, count := range c.processorsByTag {
+			if coverage, exists := v.coverage[key]; exists {
+				coverage.ProcessorsRun += count
+				v.coverage[key] = coverage
+			}
+		}
+	}
+}
+
+// unusedTagFindings returns a finding of the given severity for every registered tag key no struct has, in sorted order.
+func (v *Validator) unusedTagFindings(severity Severity) []error {
+	keys := []string{}
+
+	for key, c := range v.coverage {
+		if c.Registered && c.Tags == 0 {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+	findings := make([]error, 0, len(keys))
+
+	for _, key := range keys {
+		findings = append(findings, &ValidationError{
+			Tag:       key,
+			Message:   fmt.Sprintf("Tag %v has processors, but no struct has it", key),
+			Processor: UnusedTagsProcessor,
+			Severity:  severity,
+		})
+	}
+
+	return findings
+}
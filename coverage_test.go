@@ -0,0 +1,89 @@
+package validator
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_testCoverage(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", "package models\n\ntype Customer struct {\n"+
+		"ID string `db:\"id\" json:\"id\"`\n"+
+		"Name string `db:\"name\" json:\"name\" validate:\"required\"`\n"+
+		"}\n")
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("db", "json")
+	m.AddProcessor("validte", func(tag *Tag) []error { return nil })
+	m.AddStructProcessor("db", func(s *StructInfo) []error { return nil })
+
+	result, err := m.Validate()
+
+	r.NoError(err)
+	r.Empty(result.Findings)
+	r.Equal(map[string]TagCoverage{
+		"db":      {Tags: 2, ProcessorsRun: 5, Registered: true},
+		"json":    {Tags: 2, ProcessorsRun: 4, Registered: true},
+		"validte": {Registered: true},
+	}, m.Coverage())
+
+	//The typo is a warning, or fails the run as an error
+	m.SetReportUnusedTags(true)
+	result, err = m.Validate()
+
+	r.NoError(err)
+	r.Len(result.Findings, 1)
+	r.Equal("validte", result.Findings[0].Tag)
+	r.Equal(UnusedTagsProcessor, result.Findings[0].Processor)
+	r.Equal(SeverityWarning, result.Findings[0].Severity)
+	r.Equal("Tag validte has processors, but no struct has it", result.Findings[0].Error())
+	r.False(result.FailedFor())
+
+	m.SetErrorOnUnusedTag(true)
+	result, err = m.Validate()
+
+	r.NoError(err)
+	r.Len(result.Findings, 1)
+	r.Equal(SeverityError, result.Findings[0].Severity)
+	r.True(result.FailedFor("validte"))
+	r.Equal(map[string]int{UnusedTagsProcessor: 1}, m.Summary())
+
+	m.SetReportUnusedTags(false)
+	m.SetErrorOnUnusedTag(false)
+	result, err = m.Validate()
+
+	r.NoError(err)
+	r.Empty(result.Findings)
+}
+
+func Test_testCoverageAllTags(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", "package models\n\ntype Customer struct {\n"+
+		"ID string `db:\"id\" json:\"id\"`\n"+
+		"Name string `db:\"name\" validate:\"required\"`\n"+
+		"}\n")
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddProcessor(AllTags, func(tag *Tag) []error { return nil })
+	m.AddProcessor("db", func(tag *Tag) []error { return nil })
+	m.AddStructProcessor(AllTags, func(s *StructInfo) []error { return nil })
+	m.SetErrorOnUnusedTag(true)
+
+	result, err := m.Validate()
+
+	r.NoError(err)
+	r.Empty(result.Findings)
+
+	//The wildcard covers every key it was run on
+	r.Equal(map[string]TagCoverage{
+		"db":       {Tags: 2, ProcessorsRun: 5, Registered: true},
+		"json":     {Tags: 1, ProcessorsRun: 2},
+		"validate": {Tags: 1, ProcessorsRun: 2},
+	}, m.Coverage())
+}
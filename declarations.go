@@ -0,0 +1,141 @@
+package validator
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// checkDeclarations reports the struct names declared in more than one file of a package.
+// Their tags are collected under one name, but they are validated per declaration.
+// Parsed structs of different packages are told apart by their qualified names, see packageQualifiers,
+// types read at runtime from different packages may still share a name, they aren't reported.
+func (v *Validator) checkDeclarations() []error {
+	errs := []error{}
+
+	for _, structName := range sortedStructNames(v.tags) {
+		groups := samePackageGroups(declarationGroups(v.tags[structName]))
+
+		if len(groups) < 2 {
+			continue
+		}
+
+		locations := make([]string, 0, len(groups))
+
+		for _, group := range groups {
+			if pos := group[0].GetDeclarationPos(); pos.IsValid() {
+				locations = append(locations, pos.String())
+			} else {
+				locations = append(locations, group[0].declarationKey())
+			}
+		}
+
+		last := groups[len(groups)-1][0]
+		errs = append(errs, &ValidationError{
+			Struct:    structName,
+			Message:   fmt.Sprintf("Struct %v is declared %v times: %v", structName, len(groups), strings.Join(locations, ", ")),
+			Pos:       last.GetDeclarationPos(),
+			Source:    last.source,
+			Processor: DuplicateStructsProcessor,
+		})
+	}
+
+	return errs
+}
+
+// declarationGroups splits the tags of a struct name by the declaration they belong to, in the order of the tags.
+func declarationGroups(fields []*Tag) [][]*Tag {
+	groups := [][]*Tag{}
+	index := map[string]int{}
+
+	for _, t := range fields {
+		key := t.declarationKey()
+		i, exists := index[key]
+
+		if !exists {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, nil)
+		}
+
+		groups[i] = append(groups[i], t)
+	}
+
+	return groups
+}
+
+// samePackageGroups returns the declaration groups of the package declaring the struct most often, in their order.
+func samePackageGroups(groups [][]*Tag) [][]*Tag {
+	byPackage := map[string][][]*Tag{}
+	most := ""
+
+	for _, group := range groups {
+		pkgPath := ""
+
+		if group[0].declaration != nil {
+			pkgPath = group[0].declaration.pkgPath
+		}
+
+		byPackage[pkgPath] = append(byPackage[pkgPath], group)
+
+		if len(byPackage[pkgPath]) > len(byPackage[most]) {
+			most = pkgPath
+		}
+	}
+
+	return byPackage[most]
+}
+
+// packageQualifiers returns the qualifiers of the struct names by the directory of their package, every directory holds one.
+// A qualifier is the shortest trailing part of the directory no other one ends with, e.g. billing for models/billing
+// or v1/models for api/v1/models next to api/v2/models. It returns nil if the files belong to a single package.
+func packageQualifiers(fileNames []string) map[string]string {
+	dirs := map[string][]string{}
+
+	for _, fileName := range fileNames {
+		dir := filepath.Dir(fileName)
+
+		if _, exists := dirs[dir]; !exists {
+			dirs[dir] = strings.Split(filepath.ToSlash(dir), "/")
+		}
+	}
+
+	if len(dirs) < 2 {
+		return nil
+	}
+
+	//suffix returns the last n elements of the directory, or all of them
+	suffix := func(elems []string, n int) string {
+		if n > len(elems) {
+			n = len(elems)
+		}
+
+		return strings.Join(elems[len(elems)-n:], "/")
+	}
+
+	qualifiers := make(map[string]string, len(dirs))
+
+	//Directories are distinct, so all of them have a qualifier once the longest one is reached
+	for n := 1; len(qualifiers) < len(dirs); n++ {
+		counts := map[string]int{}
+
+		for _, elems := range dirs {
+			counts[suffix(elems, n)]++
+		}
+
+		for dir, elems := range dirs {
+			if _, done := qualifiers[dir]; !done && counts[suffix(elems, n)] == 1 {
+				qualifiers[dir] = suffix(elems, n)
+			}
+		}
+	}
+
+	return qualifiers
+}
+
+// matchesQualifier reports whether the directory of a package ends with the qualifier, ignoring the case.
+func matchesQualifier(dir, qualifier string) bool {
+	dir = strings.ToLower(filepath.ToSlash(dir))
+
+	return dir == qualifier || strings.HasSuffix(dir, "/"+qualifier)
+}
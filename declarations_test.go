@@ -0,0 +1,63 @@
+package validator
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_testValidateDuplicateStructs(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", `package models
+
+type Customer struct {
+	ID   int    `+"`"+`db:"id"`+"`"+`
+	Name string `+"`"+`db:"name"`+"`"+`
+}
+`)
+	createFile("customer_v2.go", `package models
+
+type Customer struct {
+	ID    int    `+"`"+`db:"id"`+"`"+`
+	Email string `+"`"+`db:"email"`+"`"+`
+}
+`)
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("db")
+
+	structs := 0
+	m.AddStructProcessor("db", func(s *StructInfo) []error {
+		structs++
+		r.Len(s.Tags, 2)
+
+		return nil
+	})
+
+	result, err := m.Validate()
+
+	r.NoError(err)
+	r.Equal(2, structs)
+	//The ids of the two declarations don't clash, the name collision is reported instead
+	r.Len(result.Findings, 1)
+
+	finding := result.Findings[0]
+
+	r.Equal(DuplicateStructsProcessor, finding.Processor)
+	r.Equal("Customer", finding.Struct)
+	r.Contains(finding.Message, "Struct Customer is declared 2 times: ")
+	r.Contains(finding.Message, "customer.go:3:6")
+	r.Contains(finding.Message, "customer_v2.go:3:6")
+	r.True(strings.HasSuffix(finding.Pos.Filename, "customer_v2.go"))
+	r.Equal(3, finding.Pos.Line)
+
+	tags := m.TagsFor("Customer")
+
+	r.Len(tags, 4)
+	r.True(strings.HasSuffix(tags[0].GetDeclarationPos().Filename, "customer.go"))
+	r.True(strings.HasSuffix(tags[3].GetDeclarationPos().Filename, "customer_v2.go"))
+}
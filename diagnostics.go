@@ -0,0 +1,42 @@
+package validator
+
+import (
+	"sort"
+)
+
+// FileStatus is the outcome of a file in the last run, see FileDiagnostics.
+type FileStatus string
+
+const (
+	FileParsed  FileStatus = "parsed"
+	FileSkipped FileStatus = "skipped"
+	FileFailed  FileStatus = "failed"
+)
+
+// FileDiag explains what became of a Go file found in the models folders, e.g. why its structs weren't validated.
+type FileDiag struct {
+	Path   string
+	Status FileStatus
+	//Reason tells why a file was skipped, e.g. build constraints
+	Reason string
+	//Err is the error a file failed with, e.g. a syntax error
+	Err error
+	//Cached is set for a parsed file whose tags were taken from the cache, see SetCache
+	Cached bool
+}
+
+// FileDiagnostics returns the outcome of the Go files of the last run, sorted by path.
+// Files which weren't Go files and directories which weren't walked are left out, tags read from runtime types have no files.
+func (v *Validator) FileDiagnostics() []FileDiag {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	return append([]FileDiag{}, v.files...)
+}
+
+// sortFileDiags orders the diagnostics by path.
+func sortFileDiags(files []FileDiag) {
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+}
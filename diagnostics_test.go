@@ -0,0 +1,105 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_testFileDiagnostics(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", "package models\n\ntype Customer struct {\n\tName string `db:\"na-me\"`\n}\n")
+	createFile("order.go", "package models\n\ntype Order struct {\n\tNote string `db:\"note_\"`\n}\n")
+	createFile("broken.go", "package models\n\ntype Broken struct {\n")
+	createFile("customer_test.go", "package models\n")
+	createFile("windows.go", "//go:build windows\n\npackage models\n\ntype Window struct {\n\tID int `db:\"id\"`\n}\n")
+	createFile("notes.txt", "")
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.SetBuildContext("linux", "amd64", nil)
+	m.AddDefaultProcessors("db")
+
+	r.Empty(m.FileDiagnostics())
+
+	report := m.RunReport()
+
+	//The broken file doesn't keep the others from being validated
+	r.Len(report.Findings, 2)
+	r.Equal("Customer", report.Findings[0].Struct)
+	r.Equal("Order", report.Findings[1].Struct)
+	r.Len(report.Errors, 1)
+
+	dir := filepath.Join(os.Getenv("GOPATH"), "src", modelsPath)
+	files := m.FileDiagnostics()
+
+	r.Len(files, 5)
+	r.Equal(FileDiag{Path: filepath.Join(dir, "broken.go"), Status: FileFailed, Err: files[0].Err}, files[0])
+	r.ErrorContains(files[0].Err, "expected '}', found 'EOF'")
+	r.Equal(FileDiag{Path: filepath.Join(dir, "customer.go"), Status: FileParsed}, files[1])
+	r.Equal(FileDiag{Path: filepath.Join(dir, "customer_test.go"), Status: FileSkipped, Reason: "test file"}, files[2])
+	r.Equal(FileDiag{Path: filepath.Join(dir, "order.go"), Status: FileParsed}, files[3])
+	r.Equal(FileDiag{Path: filepath.Join(dir, "windows.go"), Status: FileSkipped, Reason: "build constraints"}, files[4])
+	r.Equal(files, report.Files)
+
+	doc := struct {
+		Files []map[string]interface{}
+	}{}
+
+	out := &bytes.Buffer{}
+	r.NoError(report.WriteJSON(out))
+	r.NoError(json.Unmarshal(out.Bytes(), &doc))
+
+	r.Len(doc.Files, 5)
+	r.Equal("failed", doc.Files[0]["status"])
+	r.Contains(doc.Files[0]["error"], "expected '}'")
+	r.Equal("parsed", doc.Files[1]["status"])
+	r.Equal(map[string]interface{}{"path": filepath.Join(dir, "windows.go"), "status": "skipped", "reason": "build constraints"}, doc.Files[4])
+
+	read, err := ReadJSONReport(out)
+
+	r.NoError(err)
+	r.Len(read.Files, 5)
+	r.EqualError(read.Files[0].Err, files[0].Err.Error())
+
+	//Cached files are parsed ones
+	m.SetMemoryCache(true)
+	m.Run()
+	m.Run()
+
+	r.True(m.FileDiagnostics()[1].Cached)
+	r.Equal(FileParsed, m.FileDiagnostics()[1].Status)
+}
+
+func Test_testFileDiagnosticsNoneParsed(t *testing.T) {
+	r := require.New(t)
+
+	createFile("broken.go", "package models\n\ntype Broken struct {\n")
+	createFile("other.go", "package models\n\nfunc (\n")
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("db")
+
+	_, err := m.Validate()
+
+	r.ErrorContains(err, "None of the 2 files at ")
+	r.ErrorContains(err, "could be parsed: ")
+	r.ErrorContains(err, "broken.go:3:22: expected '}', found 'EOF'")
+
+	files := m.FileDiagnostics()
+
+	r.Len(files, 2)
+	r.Equal(FileFailed, files[0].Status)
+	r.Equal(FileFailed, files[1].Status)
+
+	_, err = m.ValidateFiles("broken.go")
+
+	r.ErrorContains(err, "None of the 1 files could be parsed: ")
+	r.Len(m.FileDiagnostics(), 1)
+}
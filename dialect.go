@@ -0,0 +1,145 @@
+package validator
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Dialect is an SQL dialect whose identifier rules NewDialectProcessor checks db tags against.
+type Dialect string
+
+const (
+	DialectSQL      Dialect = "sql"
+	DialectPostgres Dialect = "postgres"
+	DialectMySQL    Dialect = "mysql"
+	DialectSQLite   Dialect = "sqlite"
+)
+
+// identifierRule is a constraint a dialect puts on unquoted identifiers, text explains it in the errors.
+type identifierRule struct {
+	name  string
+	valid func(identifier string) bool
+	text  string
+}
+
+// identifierRules holds the rules of each dialect, a dialect is added with a row.
+var identifierRules = map[Dialect][]identifierRule{
+	DialectSQL: {
+		maxRunesRule(128),
+		charactersRule("A-Za-z0-9_"),
+		leadingRule("A-Za-z", "a letter"),
+	},
+	DialectPostgres: {
+		maxBytesRule(63),
+		{
+			name:  "case",
+			valid: func(identifier string) bool { return strings.ToLower(identifier) == identifier },
+			text:  "unquoted identifiers are folded to lowercase",
+		},
+		charactersRule(`\pL\pN_$`),
+		leadingRule(`\pL_`, "a letter or an underscore"),
+	},
+	DialectMySQL: {
+		maxRunesRule(64),
+		charactersRule(`0-9a-zA-Z$_\x{0080}-\x{FFFF} `),
+		{
+			name:  "trailing space",
+			valid: func(identifier string) bool { return !strings.HasSuffix(identifier, " ") },
+			text:  "identifiers cannot end with a space",
+		},
+		{
+			name:  "digits",
+			valid: regexp.MustCompile(`[^0-9]`).MatchString,
+			text:  "identifiers cannot consist solely of digits",
+		},
+	},
+	DialectSQLite: {
+		charactersRule(`\pL\pN_$`),
+		leadingRule(`\pL_`, "a letter or an underscore"),
+	},
+}
+
+func maxBytesRule(max int) identifierRule {
+	return identifierRule{
+		name:  "length",
+		valid: func(identifier string) bool { return len(identifier) <= max },
+		text:  fmt.Sprintf("identifiers are limited to %v bytes", max),
+	}
+}
+
+func maxRunesRule(max int) identifierRule {
+	return identifierRule{
+		name:  "length",
+		valid: func(identifier string) bool { return utf8.RuneCountInString(identifier) <= max },
+		text:  fmt.Sprintf("identifiers are limited to %v characters", max),
+	}
+}
+
+func charactersRule(class string) identifierRule {
+	return identifierRule{
+		name:  "characters",
+		valid: regexp.MustCompile("^[" + class + "]*$").MatchString,
+		text:  fmt.Sprintf("identifiers may only contain [%v]", class),
+	}
+}
+
+func leadingRule(class, description string) identifierRule {
+	return identifierRule{
+		name:  "leading character",
+		valid: regexp.MustCompile("^[" + class + "]").MatchString,
+		text:  "identifiers must begin with " + description,
+	}
+}
+
+// IdentifierDialects returns the dialects known to NewDialectProcessor.
+func IdentifierDialects() []Dialect {
+	dialects := make([]Dialect, 0, len(identifierRules))
+
+	for dialect := range identifierRules {
+		dialects = append(dialects, dialect)
+	}
+
+	sort.Slice(dialects, func(i, j int) bool {
+		return dialects[i] < dialects[j]
+	})
+
+	return dialects
+}
+
+// NewDialectProcessor creates a processor reporting db tag values which break the rules the dialect has for unquoted identifiers,
+// e.g. postgres limits them to 63 bytes and folds them to lowercase. The errors name the rule which was broken.
+// Options after the first comma aren't checked and empty names are left to the default processors. See NewReservedWordsProcessor for reserved words.
+func NewDialectProcessor(dialect Dialect) (func(tag *Tag) []error, error) {
+	rules, exists := identifierRules[dialect]
+
+	if !exists {
+		known := []string{}
+
+		for _, d := range IdentifierDialects() {
+			known = append(known, string(d))
+		}
+
+		return nil, fmt.Errorf("Unknown dialect %v, expected one of %v", dialect, strings.Join(known, ", "))
+	}
+
+	return func(tag *Tag) []error {
+		errs := []error{}
+		name, _, _ := strings.Cut(tag.GetValue(), ",")
+
+		if len(name) == 0 || tag.GetValue() == SkipTag {
+			return errs
+		}
+
+		for _, rule := range rules {
+			if !rule.valid(name) {
+				errs = append(errs, fmt.Errorf("Tag value %v in %v.%v breaks the %v %v rule: %v",
+					name, tag.GetStructName(), tag.GetName(), dialect, rule.name, rule.text))
+			}
+		}
+
+		return errs
+	}, nil
+}
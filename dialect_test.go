@@ -0,0 +1,104 @@
+package validator
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_testDialectProcessor(t *testing.T) {
+	r := require.New(t)
+
+	long := strings.Repeat("a", 64)
+	longer := strings.Repeat("b", 129)
+
+	createFile("customer.go", `package models
+
+type Customer struct {
+	Long     string `+"`"+`db:"`+long+`"`+"`"+`
+	Longer   string `+"`"+`db:"`+longer+`"`+"`"+`
+	UserName string `+"`"+`db:"UserName,omitempty"`+"`"+`
+	Dash     string `+"`"+`db:"user-name"`+"`"+`
+	First    string `+"`"+`db:"1st_name"`+"`"+`
+	Number   string `+"`"+`db:"123"`+"`"+`
+	Spaced   string `+"`"+`db:"name "`+"`"+`
+	Skipped  string `+"`"+`db:"-"`+"`"+`
+	Valid    string `+"`"+`db:"valid_name"`+"`"+`
+}
+`)
+	defer os.RemoveAll("./models")
+
+	expected := map[Dialect][]string{
+		DialectSQL: {
+			longer + ": length",
+			"user-name: characters",
+			"1st_name: leading character",
+			"123: leading character",
+			"name : characters",
+		},
+		DialectPostgres: {
+			long + ": length",
+			longer + ": length",
+			"UserName: case",
+			"user-name: characters",
+			"1st_name: leading character",
+			"123: leading character",
+			"name : characters",
+		},
+		DialectMySQL: {
+			longer + ": length",
+			"user-name: characters",
+			"123: digits",
+			"name : trailing space",
+		},
+		DialectSQLite: {
+			"user-name: characters",
+			"1st_name: leading character",
+			"123: leading character",
+			"name : characters",
+		},
+	}
+
+	r.Equal([]Dialect{DialectMySQL, DialectPostgres, DialectSQL, DialectSQLite}, IdentifierDialects())
+
+	for dialect, violations := range expected {
+		processor, err := NewDialectProcessor(dialect)
+		r.NoError(err)
+
+		m := NewValidator(modelsPath)
+		m.AddProcessor("db", processor)
+
+		broken := []string{}
+
+		for _, err := range m.Run() {
+			value, rest, found := strings.Cut(strings.TrimPrefix(err.Error(), "Tag value "), " in Customer.db breaks the "+string(dialect)+" ")
+			r.True(found, err.Error())
+
+			rule, _, found := strings.Cut(rest, " rule: ")
+			r.True(found, err.Error())
+
+			broken = append(broken, value+": "+rule)
+		}
+
+		r.ElementsMatch(violations, broken, dialect)
+	}
+
+	processor, err := NewDialectProcessor(DialectPostgres)
+	r.NoError(err)
+
+	m := NewValidator(modelsPath)
+	m.AddProcessor("db", processor)
+
+	messages := []string{}
+
+	for _, err := range m.Run() {
+		messages = append(messages, err.Error())
+	}
+
+	r.Contains(messages, "Tag value UserName in Customer.db breaks the postgres case rule: unquoted identifiers are folded to lowercase")
+
+	_, err = NewDialectProcessor("oracle")
+	r.EqualError(err, "Unknown dialect oracle, expected one of mysql, postgres, sql, sqlite")
+}
@@ -0,0 +1,177 @@
+package validator
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const diffContext = 3
+
+// diffOp is a line of a diff, kind is one of ' ', '-' or '+'.
+type diffOp struct {
+	kind byte
+	line string
+}
+
+// writeUnifiedDiff writes the difference between two versions of a file in the unified format.
+func writeUnifiedDiff(w io.Writer, path string, before, after []byte) error {
+	ops := diffLines(splitLines(before), splitLines(after))
+	buf := &bytes.Buffer{}
+
+	fmt.Fprintf(buf, "--- %v\n+++ %v\n", path, path)
+
+	for start := 0; start < len(ops); {
+		//Find the next change
+		for start < len(ops) && ops[start].kind == ' ' {
+			start++
+		}
+
+		if start == len(ops) {
+			break
+		}
+
+		//A hunk ends when more than two times the context lines are unchanged
+		end := start
+
+		for i := start; i < len(ops); i++ {
+			if ops[i].kind != ' ' {
+				end = i + 1
+			} else if i-end >= 2*diffContext {
+				break
+			}
+		}
+
+		from := max(start-diffContext, 0)
+		to := min(end+diffContext, len(ops))
+		writeHunk(buf, ops, from, to)
+		start = to
+	}
+
+	_, err := w.Write(buf.Bytes())
+
+	return err
+}
+
+func writeHunk(buf *bytes.Buffer, ops []diffOp, from, to int) {
+	beforeLine, afterLine := 1, 1
+
+	for _, op := range ops[:from] {
+		if op.kind != '+' {
+			beforeLine++
+		}
+
+		if op.kind != '-' {
+			afterLine++
+		}
+	}
+
+	beforeCount, afterCount := 0, 0
+
+	for _, op := range ops[from:to] {
+		if op.kind != '+' {
+			beforeCount++
+		}
+
+		if op.kind != '-' {
+			afterCount++
+		}
+	}
+
+	//Empty ranges start at the line before them
+	if beforeCount == 0 {
+		beforeLine--
+	}
+
+	if afterCount == 0 {
+		afterLine--
+	}
+
+	fmt.Fprintf(buf, "@@ -%v,%v +%v,%v @@\n", beforeLine, beforeCount, afterLine, afterCount)
+
+	for _, op := range ops[from:to] {
+		buf.WriteByte(op.kind)
+		buf.WriteString(op.line)
+
+		if !strings.HasSuffix(op.line, "\n") {
+			buf.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+func splitLines(content []byte) []string {
+	lines := strings.SplitAfter(string(content), "\n")
+
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
+
+// diffLines computes the line operations turning before into after.
+// Common leading and trailing lines are trimmed first, tag fixes only touch a few lines.
+func diffLines(before, after []string) []diffOp {
+	prefix := 0
+
+	for prefix < len(before) && prefix < len(after) && before[prefix] == after[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+
+	for suffix < len(before)-prefix && suffix < len(after)-prefix &&
+		before[len(before)-1-suffix] == after[len(after)-1-suffix] {
+		suffix++
+	}
+
+	ops := make([]diffOp, 0, len(before)+len(after))
+
+	for _, line := range before[:prefix] {
+		ops = append(ops, diffOp{' ', line})
+	}
+
+	a := before[prefix : len(before)-suffix]
+	b := after[prefix : len(after)-suffix]
+
+	//Longest common subsequence of the changed middle
+	lcs := make([][]int, len(a)+1)
+
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, diffOp{' ', a[i]})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, diffOp{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', b[j]})
+			j++
+		}
+	}
+
+	for _, line := range before[len(before)-suffix:] {
+		ops = append(ops, diffOp{' ', line})
+	}
+
+	return ops
+}
@@ -0,0 +1,125 @@
+package validator
+
+import (
+	"fmt"
+	"go/ast"
+	"path"
+	"strings"
+)
+
+// DirectivePrefix starts the lines of a struct's doc comment holding directives for the processors,
+// e.g. `// tagvalidator:table=customer_accounts`. A line may hold several space separated key=value pairs.
+const DirectivePrefix = "tagvalidator:"
+
+// TableDirective overrides the table name of a struct, see StructInfo.TableName.
+const TableDirective = "table"
+
+// IgnoreDirective leaves fields of a struct out, like ExcludeFields does for all structs, e.g. `// tagvalidator:ignore=Cache,Internal*`.
+// Its value is a comma separated list of field names or glob patterns.
+const IgnoreDirective = "ignore"
+
+// StructDoc returns the doc comment of the struct the tag belongs to, it is empty for tags read from runtime types.
+func (t *Tag) StructDoc() string {
+	if t == nil || t.declaration == nil {
+		return ""
+	}
+
+	return t.declaration.doc
+}
+
+// StructDirective returns the value of a directive in the doc comment of the struct the tag belongs to, see DirectivePrefix.
+func (t *Tag) StructDirective(key string) (string, bool) {
+	if t == nil || t.declaration == nil {
+		return "", false
+	}
+
+	value, exists := t.declaration.directives[key]
+
+	return value, exists
+}
+
+// TableName returns the table of the struct, given by the table directive of its doc comment or else its snake cased name.
+func (s *StructInfo) TableName() string {
+	if table, exists := s.Directives[TableDirective]; exists && len(table) > 0 {
+		return table
+	}
+
+	return SnakeCase(s.Name)
+}
+
+// ignores reports whether the ignore directive of the declaration leaves the field out.
+func (d *declaration) ignores(fieldName string) bool {
+	if d == nil {
+		return false
+	}
+
+	return ignoredBy(d.directives[IgnoreDirective], fieldName)
+}
+
+// ignoredBy reports whether the field matches one of the patterns of an ignore directive.
+func ignoredBy(patterns string, fieldName string) bool {
+	if len(patterns) == 0 {
+		return false
+	}
+
+	for _, pattern := range strings.Split(patterns, ",") {
+		if ok, _ := path.Match(pattern, fieldName); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseDirectives returns the directives of a doc comment by key, along with the pairs which aren't key=value.
+// Keys are kept whether a processor knows them or not, a key given twice keeps its last value.
+func parseDirectives(doc *ast.CommentGroup) (map[string]string, []string) {
+	directives := map[string]string{}
+	malformed := []string{}
+
+	if doc == nil {
+		return directives, malformed
+	}
+
+	for _, comment := range doc.List {
+		text := strings.TrimPrefix(comment.Text, "//")
+		text = strings.TrimSuffix(strings.TrimPrefix(text, "/*"), "*/")
+
+		for _, line := range strings.Split(text, "\n") {
+			line = strings.TrimSpace(line)
+
+			if !strings.HasPrefix(line, DirectivePrefix) {
+				continue
+			}
+
+			for _, pair := range strings.Fields(strings.TrimPrefix(line, DirectivePrefix)) {
+				key, value, found := strings.Cut(pair, "=")
+
+				if !found || len(key) == 0 {
+					malformed = append(malformed, pair)
+					continue
+				}
+
+				directives[key] = value
+			}
+		}
+	}
+
+	return directives, malformed
+}
+
+// newDirectivesError reports the malformed directives of a struct once.
+func newDirectivesError(structName string, decl *declaration, malformed []string) error {
+	quoted := make([]string, 0, len(malformed))
+
+	for _, pair := range malformed {
+		quoted = append(quoted, fmt.Sprintf("%q", pair))
+	}
+
+	return &ValidationError{
+		Struct:    structName,
+		Message:   fmt.Sprintf("Malformed directives %v on %v, expected key=value", strings.Join(quoted, ", "), structName),
+		Pos:       decl.pos,
+		Processor: DirectivesProcessor,
+	}
+}
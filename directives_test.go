@@ -0,0 +1,83 @@
+package validator
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_testStructDirectives(t *testing.T) {
+	r := require.New(t)
+
+	createFile("account.go", `package models
+
+// Account is a legacy model, its table predates the naming rules.
+// tagvalidator:table=customer_accounts owner=billing
+type Account struct {
+	ID int `+"`"+`db:"id"`+"`"+`
+}
+
+type (
+	// Order has no table directive.
+	Order struct {
+		ID int `+"`"+`db:"id"`+"`"+`
+	}
+
+	//tagvalidator:table=invoice_archive
+	Invoice struct {
+		ID int `+"`"+`db:"id"`+"`"+`
+	}
+)
+
+/*
+Payment has a malformed directive.
+tagvalidator:table=payments cached =yes
+*/
+type Payment struct {
+	ID int `+"`"+`db:"id"`+"`"+`
+}
+`)
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+
+	tables := map[string]string{}
+	docs := map[string]string{}
+	owners := map[string]string{}
+
+	m.AddStructProcessor("db", func(s *StructInfo) []error {
+		tables[s.Name] = s.TableName()
+		docs[s.Name] = s.Doc
+		owners[s.Name] = s.Directives["owner"]
+
+		return nil
+	})
+
+	result, err := m.Validate()
+
+	r.NoError(err)
+	r.Equal(map[string]string{
+		"Account": "customer_accounts",
+		"Order":   "order",
+		"Invoice": "invoice_archive",
+		"Payment": "payments",
+	}, tables)
+	r.Equal("Account is a legacy model, its table predates the naming rules.\ntagvalidator:table=customer_accounts owner=billing\n", docs["Account"])
+	r.Equal("Order has no table directive.\n", docs["Order"])
+	r.Equal("billing", owners["Account"])
+
+	//The malformed pairs are reported once, the others are kept
+	r.Len(result.Findings, 1)
+	r.Equal(DirectivesProcessor, result.Findings[0].Processor)
+	r.Equal("Payment", result.Findings[0].Struct)
+	r.Equal(`Malformed directives "cached", "=yes" on Payment, expected key=value`, result.Findings[0].Message)
+	r.Equal(25, result.Findings[0].Pos.Line)
+
+	account := m.TagsFor("Account")[0]
+	table, found := account.StructDirective(TableDirective)
+
+	r.True(found)
+	r.Equal("customer_accounts", table)
+	r.Contains(account.StructDoc(), "legacy model")
+}
@@ -0,0 +1,122 @@
+package validator
+
+import (
+	"go/token"
+	"io/fs"
+	"sort"
+	"strconv"
+)
+
+// TextEdit replaces the bytes of a file from Pos up to End with NewText, e.g. for the code actions of an editor.
+// The positions are those of the file as it was validated, their offsets are byte offsets.
+type TextEdit struct {
+	Pos     token.Position
+	End     token.Position
+	NewText string
+}
+
+// addEdits computes the edits of the fixable findings against the files they were reported for.
+// A finding whose edit overlaps the different edit of another finding is made unfixable, Fix reports the conflict.
+// Findings of tags read from runtime types or about a whole field get no edits, neither do replacements
+// a raw string literal can't hold, Fix rewrites the whole literal for those.
+func addEdits(fsys fs.FS, findings []*ValidationError) {
+	byFile := map[string][]*ValidationError{}
+
+	for _, finding := range findings {
+		if !finding.Fixable || len(finding.Source) > 0 || !finding.ValuePos.IsValid() || !finding.ValueEnd.IsValid() {
+			continue
+		}
+
+		byFile[finding.Pos.Filename] = append(byFile[finding.Pos.Filename], finding)
+	}
+
+	for path, fileFindings := range byFile {
+		src, err := readFile(fsys, path)
+
+		if err != nil {
+			continue
+		}
+
+		edited := []*ValidationError{}
+
+		for _, finding := range fileFindings {
+			start, end := finding.ValuePos.Offset, finding.ValueEnd.Offset
+
+			if finding.Pos.Offset >= len(src) || start > end || end > len(src) {
+				continue
+			}
+
+			text, ok := editText(src[finding.Pos.Offset], finding.Replacement)
+
+			if !ok {
+				continue
+			}
+
+			finding.Edits = []TextEdit{{Pos: finding.ValuePos, End: finding.ValueEnd, NewText: text}}
+			edited = append(edited, finding)
+		}
+
+		for _, finding := range overlappingEdits(edited) {
+			finding.Fixable = false
+			finding.Edits = nil
+			finding.conflict = true
+		}
+	}
+}
+
+// editText returns the text replacing a value within a tag literal opened by the given quote, the value keeps its own quotes.
+// It is false if the literal can't hold the replacement.
+func editText(quote byte, replacement string) (string, bool) {
+	value := strconv.Quote(replacement)
+	value = value[1 : len(value)-1]
+
+	switch quote {
+	case '`':
+		return value, strconv.CanBackquote(value)
+	case '"':
+		//The value is inside an interpreted string, so it is escaped once more
+		quoted := strconv.Quote(value)
+
+		return quoted[1 : len(quoted)-1], true
+	}
+
+	return "", false
+}
+
+// overlappingEdits returns the findings of one file whose edit overlaps a different edit of another finding.
+// Findings making the same edit, e.g. two processors suggesting the same replacement, don't conflict.
+func overlappingEdits(findings []*ValidationError) []*ValidationError {
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Edits[0].Pos.Offset < findings[j].Edits[0].Pos.Offset
+	})
+
+	conflicts := map[*ValidationError]bool{}
+
+	for i, a := range findings {
+		for _, b := range findings[i+1:] {
+			x, y := a.Edits[0], b.Edits[0]
+
+			//Edits are sorted by their start, so none of the following ones can overlap this one
+			if y.Pos.Offset >= x.End.Offset && y.Pos.Offset > x.Pos.Offset {
+				break
+			}
+
+			if x.Pos.Offset == y.Pos.Offset && x.End.Offset == y.End.Offset && x.NewText == y.NewText {
+				continue
+			}
+
+			conflicts[a] = true
+			conflicts[b] = true
+		}
+	}
+
+	overlapping := []*ValidationError{}
+
+	for _, finding := range findings {
+		if conflicts[finding] {
+			overlapping = append(overlapping, finding)
+		}
+	}
+
+	return overlapping
+}
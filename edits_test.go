@@ -0,0 +1,222 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// applyEdits applies the edits of the findings to their files, like an editor applying every code action would.
+func applyEdits(r *require.Assertions, findings []*ValidationError) {
+	byFile := map[string][]TextEdit{}
+
+	for _, finding := range findings {
+		for _, edit := range finding.Edits {
+			byFile[edit.Pos.Filename] = append(byFile[edit.Pos.Filename], edit)
+		}
+	}
+
+	for path, edits := range byFile {
+		src, err := os.ReadFile(path)
+		r.NoError(err)
+
+		//Applied from the end, so the offsets of the edits before stay valid, the same edit is applied once
+		sort.Slice(edits, func(i, j int) bool {
+			return edits[i].Pos.Offset > edits[j].Pos.Offset
+		})
+
+		for i, edit := range edits {
+			if i > 0 && edit == edits[i-1] {
+				continue
+			}
+
+			src = append(append(append([]byte{}, src[:edit.Pos.Offset]...), edit.NewText...), src[edit.End.Offset:]...)
+		}
+
+		r.NoError(os.WriteFile(path, src, 0644))
+	}
+}
+
+func Test_testEditsRoundTrip(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", fixModel)
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("db", "json")
+
+	result, err := m.Validate()
+	r.NoError(err)
+	r.NotEmpty(result.Findings)
+
+	for _, finding := range result.Findings {
+		r.True(finding.Fixable, finding.Error())
+		r.Len(finding.Edits, 1, finding.Error())
+	}
+
+	applyEdits(r, result.Findings)
+
+	content, err := os.ReadFile(filepath.Join("models", "customer.go"))
+	r.NoError(err)
+	r.Contains(string(content), "`json:\"name\" db:\"name\" validate:\"required\"` // the name")
+	r.Contains(string(content), `"db:\"updated_at\""`)
+	r.Empty(m.Run())
+}
+
+func Test_testEditsEscaped(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", `package models
+
+type Customer struct {
+	Name string "db:\"name_\""
+	Note string `+"`db:\"note_\"`"+`
+}
+`)
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddProcessor("db", func(tag *Tag) []error {
+		if tag.GetValue() == "name_" {
+			return []error{NewFixableError(errors.New("quoted"), `say "hi"`)}
+		}
+
+		return []error{NewFixableError(errors.New("backquoted"), "`note`")}
+	})
+
+	result, err := m.Validate()
+	r.NoError(err)
+	r.Len(result.Findings, 2)
+
+	//The interpreted literal escapes the quotes of the replacement once more
+	r.Equal("name_", result.Findings[0].Value)
+	r.Equal([]TextEdit{{
+		Pos:     result.Findings[0].ValuePos,
+		End:     result.Findings[0].ValueEnd,
+		NewText: `say \\\"hi\\\"`,
+	}}, result.Findings[0].Edits)
+
+	//A raw string literal can't hold a backquote, Fix rewrites the whole literal instead
+	r.Equal("note_", result.Findings[1].Value)
+	r.True(result.Findings[1].Fixable)
+	r.Empty(result.Findings[1].Edits)
+
+	applyEdits(r, result.Findings)
+
+	content, err := os.ReadFile(filepath.Join("models", "customer.go"))
+	r.NoError(err)
+	r.Contains(string(content), `Name string "db:\"say \\\"hi\\\"\""`)
+
+	tags, err := m.ListTags()
+	r.NoError(err)
+	r.Equal(`say "hi"`, tags["Customer"][0].GetValue())
+}
+
+func Test_testEditsOverlapping(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", fixModel)
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+
+	for _, replacement := range []string{"a", "b", "b"} {
+		replacement := replacement
+
+		m.AddProcessor("db", func(tag *Tag) []error {
+			switch tag.GetValue() {
+			case "name_":
+				return []error{NewFixableError(errors.New("wrong"), replacement)}
+			case "created_at__":
+				return []error{NewFixableError(errors.New("wrong"), "created_at")}
+			}
+
+			return nil
+		})
+	}
+
+	result, err := m.Validate()
+	r.NoError(err)
+	r.Len(result.Findings, 6)
+
+	for _, finding := range result.Findings {
+		//Different replacements of the same value conflict, the same replacement doesn't
+		if finding.Value == "name_" {
+			r.False(finding.Fixable)
+			r.Empty(finding.Edits)
+			continue
+		}
+
+		r.True(finding.Fixable)
+		r.Len(finding.Edits, 1)
+	}
+}
+
+func Test_testEditsReport(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", fixModel)
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("json")
+
+	report := m.RunReport()
+	r.Len(report.Findings, 1)
+
+	edit := report.Findings[0].Edits[0]
+	buf := &bytes.Buffer{}
+	r.NoError(report.WriteJSON(buf))
+
+	doc := struct {
+		Findings []struct {
+			Edits []map[string]interface{} `json:"edits"`
+		} `json:"findings"`
+	}{}
+
+	r.NoError(json.Unmarshal(buf.Bytes(), &doc))
+	r.Equal([]map[string]interface{}{{
+		"offset":     float64(edit.Pos.Offset),
+		"end_offset": float64(edit.End.Offset),
+		"line":       float64(8),
+		"column":     float64(29),
+		"end_line":   float64(8),
+		"end_column": float64(34),
+		"new_text":   "name",
+	}}, doc.Findings[0].Edits)
+
+	read, err := ReadJSONReport(buf)
+	r.NoError(err)
+	r.True(read.Findings[0].Fixable)
+	r.Equal(edit.NewText, read.Findings[0].Edits[0].NewText)
+	r.Equal(edit.Pos.Offset, read.Findings[0].Edits[0].Pos.Offset)
+
+	buf.Reset()
+	r.NoError(report.WriteSARIF(buf))
+
+	sarif := struct {
+		Runs []struct {
+			Results []struct {
+				Fixes []sarifFix `json:"fixes"`
+			} `json:"results"`
+		} `json:"runs"`
+	}{}
+
+	r.NoError(json.Unmarshal(buf.Bytes(), &sarif))
+
+	fixes := sarif.Runs[0].Results[0].Fixes
+	r.Len(fixes, 1)
+	r.Equal("Replace name_ with name", fixes[0].Description.Text)
+
+	replacement := fixes[0].ArtifactChanges[0].Replacements[0]
+	r.Equal(edit.Pos.Offset, *replacement.DeletedRegion.ByteOffset)
+	r.Equal(5, *replacement.DeletedRegion.ByteLength)
+	r.Equal("name", replacement.InsertedContent.Text)
+}
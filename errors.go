@@ -0,0 +1,167 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"runtime/debug"
+	"strings"
+)
+
+// ErrProcessorPanic is wrapped by the errors reported for processors which panicked.
+var ErrProcessorPanic = errors.New("processor panicked")
+
+// ErrTimeout is wrapped by the error of a run that took longer than the timeout set with SetTimeout.
+var ErrTimeout = errors.New("run timed out")
+
+// ValidationError is a finding produced while validating a tag.
+// Errors returned by processors are wrapped into it, so the original error can still be retrieved with errors.Is and errors.As.
+type ValidationError struct {
+	Struct  string
+	Field   string
+	Tag     string
+	Value   string
+	Message string
+	Pos     token.Position
+	// ValuePos and ValueEnd locate the tag value within the literal, they are zero for findings about a whole field.
+	ValuePos token.Position
+	ValueEnd token.Position
+	// Kind identifies the findings of the default processors and checks, it is empty for the findings of other processors.
+	Kind MessageKind
+	// Processor is the name of the processor which produced the finding, see Validator.Summary.
+	Processor string
+	// Severity is SeverityError unless the processor or the tag made it a warning, see Validator.SetSeverity.
+	Severity Severity
+	// Source is SourceReflection for tags read from runtime types, it is empty for tags parsed from files.
+	Source string
+	// Suggestion is an optional hint on how to resolve the finding.
+	Suggestion string
+	// Fixable reports whether Replacement can be applied to the tag value by Fix.
+	Fixable     bool
+	Replacement string
+	// Edits apply the replacement to the file the finding was reported for, see TextEdit.
+	Edits []TextEdit
+	//conflict marks a finding made unfixable by an edit of another finding, Fix reports it
+	conflict bool
+	err      error
+}
+
+// Error returns the message of the finding, followed by the suggestion if there is one.
+func (e *ValidationError) Error() string {
+	if len(e.Suggestion) > 0 {
+		return fmt.Sprintf("%v (suggested: %v)", e.Message, e.Suggestion)
+	}
+
+	return e.Message
+}
+
+// Unwrap returns the error returned by the processor.
+func (e *ValidationError) Unwrap() error {
+	return e.err
+}
+
+// NewErrorWithSuggestion wraps an error returned by a processor with a hint on how to resolve it.
+func NewErrorWithSuggestion(err error, suggestion string) *ValidationError {
+	return &ValidationError{
+		Message:    err.Error(),
+		Suggestion: suggestion,
+		err:        err,
+	}
+}
+
+// NewFixableError wraps an error returned by a processor with a replacement for the tag value.
+// Fix applies the replacement to the source of the model, it is suggested in the error message as well.
+func NewFixableError(err error, replacement string) *ValidationError {
+	return &ValidationError{
+		Message:     err.Error(),
+		Suggestion:  replacement,
+		Fixable:     true,
+		Replacement: replacement,
+		err:         err,
+	}
+}
+
+// newValidationError wraps an error produced for the given tag.
+// A ValidationError returned by a processor gets the details of the tag filled in.
+func newValidationError(t *Tag, err error) *ValidationError {
+	var ve *ValidationError
+
+	if errors.As(err, &ve) {
+		if len(ve.Struct) == 0 && len(ve.Tag) == 0 {
+			ve.Struct = t.GetStructName()
+			ve.Field = t.GetFieldName()
+			ve.Tag = t.GetName()
+			ve.Value = t.GetValue()
+			ve.Pos = t.GetPosition()
+			ve.ValuePos, ve.ValueEnd = t.GetValuePos()
+			ve.Source = t.source
+		}
+
+		return ve
+	}
+
+	start, end := t.GetValuePos()
+
+	return &ValidationError{
+		Struct:   t.GetStructName(),
+		Field:    t.GetFieldName(),
+		Tag:      t.GetName(),
+		Value:    t.GetValue(),
+		Message:  err.Error(),
+		Pos:      t.GetPosition(),
+		ValuePos: start,
+		ValueEnd: end,
+		Source:   t.source,
+		err:      err,
+	}
+}
+
+// wrapErrors wraps every error produced for the given tag into a ValidationError.
+func wrapErrors(t *Tag, errs []error) []error {
+	for i, err := range errs {
+		errs[i] = newValidationError(t, err)
+	}
+
+	return errs
+}
+
+// newPanicError describes a processor panic on the given tag, along with the location it happened at.
+func newPanicError(t *Tag, value interface{}) error {
+	err := fmt.Errorf("%w for tag %v on %v.%v: %v", ErrProcessorPanic, t.GetName(), t.GetStructName(), t.GetFieldName(), value)
+
+	if location := panicLocation(debug.Stack()); len(location) > 0 {
+		err = fmt.Errorf("%w at %v", err, location)
+	}
+
+	return err
+}
+
+// panicLocation returns the function and the file and line which panicked from a stack trace.
+// The first frame after the call to panic is the one which panicked.
+func panicLocation(stack []byte) string {
+	lines := strings.Split(string(stack), "\n")
+
+	for i := 0; i+3 < len(lines); i++ {
+		if !strings.HasPrefix(lines[i], "panic(") {
+			continue
+		}
+
+		file := strings.TrimSpace(lines[i+3])
+
+		//Drops the program counter offset, e.g. `file.go:12 +0x1d`
+		if end := strings.LastIndex(file, " +0x"); end > 0 {
+			file = file[:end]
+		}
+
+		//Drops the arguments, e.g. `pkg.fn(0xc000012345)`
+		function := lines[i+2]
+
+		if end := strings.LastIndex(function, "("); end > 0 {
+			function = function[:end]
+		}
+
+		return fmt.Sprintf("%v (%v)", function, file)
+	}
+
+	return ""
+}
@@ -0,0 +1,233 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/token"
+	"path/filepath"
+	"sort"
+)
+
+// SetFullDuplicates widens the duplicate values check of ValidateFiles to all files in the packages of the given files.
+// A value already held in a file that wasn't given is reported as well. The other files are parsed, but not validated.
+func (v *Validator) SetFullDuplicates(full bool) {
+	v.fullDuplicates = full
+}
+
+// ValidateFiles validates only the given Go files, e.g. the ones changed in a commit.
+// Relative paths are resolved against the models path first, then against the working directory.
+// Paths which don't exist or aren't Go files are reported as warnings, the other files are validated anyway.
+// Duplicate tag values are looked for among the given files, see SetFullDuplicates.
+func (v *Validator) ValidateFiles(paths ...string) (*RunResult, error) {
+	r := v.snapshot()
+	defer v.publish(r)
+
+	return r.validate(context.Background(), func(ctx context.Context, tags []string) (collection, error) {
+		return r.collectFiles(ctx, tags, paths)
+	})
+}
+
+// RunFiles works like ValidateFiles, but returns the warnings, findings and the error in one slice, like Run.
+func (v *Validator) RunFiles(paths ...string) []error {
+	return runErrors(v.ValidateFiles(paths...))
+}
+
+// collectFiles parses the given files and collects the given tags.
+func (v *Validator) collectFiles(ctx context.Context, tags []string, paths []string) (collection, error) {
+	if len(paths) == 0 {
+		return collection{}, errors.New("No files to validate")
+	}
+
+	fileNames, errs := v.resolveFiles(paths)
+
+	if len(fileNames) == 0 {
+		return collection{}, errors.Join(errs...)
+	}
+
+	v.fset = token.NewFileSet()
+	//The files of other packages read for SetFullDuplicates are in the same directories, so they are qualified alike
+	v.qualifiers = packageQualifiers(fileNames)
+	col := v.newCollector(tags)
+	c, err := getTags(ctx, col, fileNames, v.concurrency, v.retainAST)
+
+	if err != nil {
+		return collection{}, err
+	}
+
+	v.stats.FilesParsed = len(fileNames) - len(c.errs)
+	v.stats.FilesCached = c.filesCached
+	v.stats.StructsFound = len(c.tags)
+	v.stats.FieldsExcluded = c.fieldsExcluded
+	v.files = c.files
+	sortFileDiags(v.files)
+
+	if col.cache != nil {
+		col.cache.save(col)
+	}
+
+	if len(c.errs) == len(fileNames) {
+		return collection{}, fmt.Errorf("None of the %v files could be parsed: %w", len(fileNames), errors.Join(c.errs...))
+	}
+
+	c.errs = append(errs, c.errs...)
+	c.warnings = v.filterStructs(&c)
+	v.packages = c.packages
+	v.tags = c.tags
+
+	if v.fullDuplicates && !v.allowDuplicates {
+		findings, err := v.packageDuplicates(ctx, col, fileNames)
+
+		if err != nil {
+			return collection{}, err
+		}
+
+		c.findings = append(c.findings, findings...)
+		//The duplicates were checked already, the run works on a snapshot so the setting isn't kept
+		v.allowDuplicates = true
+	}
+
+	return c, nil
+}
+
+// resolveFiles resolves the paths of the files to validate.
+// Paths that don't exist or aren't Go files are returned as errors.
+func (v *Validator) resolveFiles(paths []string) ([]string, []error) {
+	root, rootErr := resolveFolder(v.fsys, v.path)
+	fileNames := make([]string, 0, len(paths))
+	errs := []error{}
+	seen := map[string]bool{}
+
+	for _, path := range paths {
+		if filepath.Ext(path) != ".go" {
+			errs = append(errs, fmt.Errorf("%v is not a Go file", path))
+			continue
+		}
+
+		candidates := []string{path}
+
+		if !filepath.IsAbs(path) && rootErr == nil {
+			candidates = []string{joinPath(v.fsys, root, path), path}
+		}
+
+		fileName := ""
+
+		for _, candidate := range candidates {
+			if info, err := statFile(v.fsys, candidate); err == nil && info.Mode().IsRegular() {
+				fileName = joinPath(v.fsys, candidate)
+				break
+			}
+		}
+
+		if len(fileName) == 0 {
+			errs = append(errs, fmt.Errorf("File %v not found", path))
+			continue
+		}
+
+		if abs, err := absPath(v.fsys, fileName); err == nil {
+			if seen[abs] {
+				continue
+			}
+
+			seen[abs] = true
+		}
+
+		fileNames = append(fileNames, fileName)
+	}
+
+	return fileNames, errs
+}
+
+// packageDuplicates reports the tags collected from the given files whose value is already held in another file of their packages.
+// The values of the other files are cached first, so the given files are the ones reported.
+func (v *Validator) packageDuplicates(ctx context.Context, col *collector, fileNames []string) ([]error, error) {
+	given := map[string]bool{}
+	dirs := []string{}
+	seenDirs := map[string]bool{}
+
+	for _, fileName := range fileNames {
+		abs, err := absPath(v.fsys, fileName)
+
+		if err != nil {
+			return nil, err
+		}
+
+		given[abs] = true
+
+		if dir := joinPath(v.fsys, abs, ".."); !seenDirs[dir] {
+			seenDirs[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+
+	_, packageFiles, err := getFiles(dirs, fileWalker{
+		ctx:    v.buildContext,
+		logger: v.logger,
+		fsys:   v.fsys,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	others := []string{}
+
+	for _, fileName := range packageFiles {
+		if abs, err := absPath(v.fsys, fileName); err == nil && !given[abs] {
+			others = append(others, fileName)
+		}
+	}
+
+	//Files that fail to parse only miss out on the cache
+	c, err := getTags(ctx, col, others, v.concurrency, false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	v.logger.Debug("parsed package files", "files", len(others), "failed", len(c.errs))
+
+	fieldsCache := map[string]*Tag{}
+	checked := func(t *Tag) bool {
+		return !v.allowDuplicateValues[t.GetName()] && !v.isSkipped(t)
+	}
+
+	//Structs are walked in order, so the same duplicates are reported every run
+	for _, structName := range sortedStructNames(c.tags) {
+		//Values only clash within a struct name, the ones filtered out are not validated
+		if _, exists := v.tags[structName]; !exists {
+			continue
+		}
+
+		for _, t := range c.tags[structName] {
+			if checked(t) {
+				checkForDuplicates(t, t.GetStructName(), fieldsCache, v.messages)
+			}
+		}
+	}
+
+	errs := []error{}
+
+	for _, structName := range sortedStructNames(v.tags) {
+		for _, t := range v.tags[structName] {
+			if checked(t) {
+				errs = append(errs, attributeErrors(wrapErrors(t, checkForDuplicates(t, t.GetStructName(), fieldsCache, v.messages)), DuplicatesProcessor)...)
+			}
+		}
+	}
+
+	return errs, nil
+}
+
+// sortedStructNames returns the struct names of the tags in order.
+func sortedStructNames(tags map[string][]*Tag) []string {
+	names := make([]string, 0, len(tags))
+
+	for name := range tags {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
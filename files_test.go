@@ -0,0 +1,74 @@
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_testValidateFiles(t *testing.T) {
+	r := require.New(t)
+
+	createModel("customer.go", []structTpl{{"Customer", "created_at", "updated_at", ""}})
+	createModel("customer1.go", []structTpl{{"Customer", "created_at", "updated_at", ""}})
+	createFile("order.go", `package models
+
+type Order struct {
+	ID   int    `+"`"+`db:"id"`+"`"+`
+	Name string `+"`"+`db:"name_"`+"`"+`
+}
+`)
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("db")
+
+	errs := m.RunFiles("order.go", "missing.go", "README.md")
+	messages := []string{}
+
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+
+	r.Equal([]string{
+		"File missing.go not found",
+		"README.md is not a Go file",
+		"Tag cannot end on _ in  Order.db.name_, charset [a-z0-9_, ] (suggested: name)",
+	}, messages)
+	r.Equal(1, m.Stats().FilesParsed)
+
+	//The duplicates are in another file, which isn't parsed
+	result, err := m.ValidateFiles("customer1.go")
+
+	r.NoError(err)
+	r.Empty(result.Findings)
+	r.Len(m.Tags()["Customer"], 3)
+
+	m.SetFullDuplicates(true)
+	result, err = m.ValidateFiles(filepath.Join(os.Getenv("GOPATH"), "src", modelsPath, "customer1.go"))
+
+	r.NoError(err)
+	r.Len(result.Findings, 3)
+
+	for _, finding := range result.Findings {
+		r.True(strings.HasPrefix(finding.Message, "Duplicate tag value"))
+		r.Equal("customer1.go", filepath.Base(finding.Pos.Filename))
+	}
+
+	//The option is kept between runs
+	result, err = m.ValidateFiles("customer1.go")
+
+	r.NoError(err)
+	r.Len(result.Findings, 3)
+
+	_, err = m.ValidateFiles("missing.go")
+
+	r.EqualError(err, "File missing.go not found")
+
+	_, err = m.ValidateFiles()
+
+	r.EqualError(err, "No files to validate")
+}
@@ -0,0 +1,119 @@
+package validator
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// IncludeStructs limits the validation to the structs whose name matches one of the glob patterns, e.g. `*Model`.
+// The patterns use the path.Match syntax and apply on top of the models passed to Run.
+// Once struct names are qualified with their package, see Validate, patterns match both names, e.g. `billing.*` and `Invoice`,
+// as well as the name qualified with a trailing part of the package path, e.g. `models.Invoice` for v1/models.Invoice.
+func (v *Validator) IncludeStructs(patterns ...string) {
+	v.includeStructs = append(v.includeStructs, patterns...)
+}
+
+// ExcludeStructs leaves out the structs whose name matches one of the glob patterns, e.g. `Audit*`.
+// Exclusion wins over inclusion.
+func (v *Validator) ExcludeStructs(patterns ...string) {
+	v.excludeStructs = append(v.excludeStructs, patterns...)
+}
+
+// filterStructs removes the tags and findings of the structs left out by the include and exclude patterns.
+// It returns a warning for every pattern which matched no struct.
+func (v *Validator) filterStructs(c *collection) []error {
+	if len(v.includeStructs) == 0 && len(v.excludeStructs) == 0 {
+		return nil
+	}
+
+	matched := map[string]bool{}
+
+	matchAny := func(name string, patterns []string) bool {
+		found := false
+		names := v.qualifiedNames(name)
+
+		for _, pattern := range patterns {
+			for _, name := range names {
+				if ok, _ := path.Match(pattern, name); ok {
+					matched[pattern] = true
+					found = true
+
+					break
+				}
+			}
+		}
+
+		return found
+	}
+
+	//Both lists are always matched, so every pattern matching a struct is recorded
+	keep := func(name string) bool {
+		excluded := matchAny(name, v.excludeStructs)
+		included := len(v.includeStructs) == 0 || matchAny(name, v.includeStructs)
+
+		return included && !excluded
+	}
+
+	for structName := range c.tags {
+		if !keep(structName) {
+			v.stats.StructsSkipped++
+			delete(c.tags, structName)
+		}
+	}
+
+	findings := c.findings[:0]
+
+	for _, finding := range c.findings {
+		if f, ok := finding.(*ValidationError); ok && !keep(f.Struct) {
+			continue
+		}
+
+		findings = append(findings, finding)
+	}
+
+	c.findings = findings
+
+	warnings := []error{}
+
+	for _, pattern := range append(append([]string{}, v.includeStructs...), v.excludeStructs...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			warnings = append(warnings, fmt.Errorf("Invalid struct pattern %v: %v", pattern, err))
+			continue
+		}
+
+		if !matched[pattern] {
+			warnings = append(warnings, fmt.Errorf("Struct pattern %v matched no struct", pattern))
+		}
+	}
+
+	return warnings
+}
+
+// qualifiedNames returns the names a struct pattern may match, the struct name itself, the name without its qualifier
+// and the name qualified with every trailing part of the package path.
+func (v *Validator) qualifiedNames(name string) []string {
+	qualifier := ""
+
+	//Qualifiers may hold dots, so the longest one prefixing the name is the one it was qualified with
+	for _, q := range v.qualifiers {
+		if strings.HasPrefix(name, q+".") && len(q) > len(qualifier) {
+			qualifier = q
+		}
+	}
+
+	if len(qualifier) == 0 {
+		return []string{name}
+	}
+
+	unqualified := strings.TrimPrefix(name, qualifier+".")
+	names := []string{name, unqualified}
+
+	for i, c := range qualifier {
+		if c == '/' {
+			names = append(names, qualifier[i+1:]+"."+unqualified)
+		}
+	}
+
+	return names
+}
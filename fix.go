@@ -0,0 +1,219 @@
+package validator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+)
+
+// tagFix is a replacement for the value of one key of a tag literal.
+type tagFix struct {
+	key         string
+	value       string
+	replacement string
+}
+
+// Fix runs the validator and applies the replacements suggested by fixable findings to the model files.
+// Other tags in the same literal are preserved and the files are printed back with go/format.
+// It returns the number of files changed.
+// Conflicting replacements for the same tag are rejected before any file is written.
+// The files of a validator created with NewValidatorFS can't be changed, an error is returned.
+func (v *Validator) Fix(models ...string) (int, error) {
+	if v.fsys != nil {
+		return 0, errReadOnlyFS
+	}
+
+	return v.fix(func(path string, before, after []byte) error {
+		info, err := os.Stat(path)
+
+		if err != nil {
+			return err
+		}
+
+		return os.WriteFile(path, after, info.Mode().Perm())
+	}, models...)
+}
+
+// FixDryRun works like Fix, but writes a unified diff of the changes to w instead of changing the files.
+func (v *Validator) FixDryRun(w io.Writer, models ...string) (int, error) {
+	return v.fix(func(path string, before, after []byte) error {
+		return writeUnifiedDiff(w, path, before, after)
+	}, models...)
+}
+
+func (v *Validator) fix(apply func(path string, before, after []byte) error, models ...string) (int, error) {
+	fixes := map[string]map[int][]tagFix{}
+	errs := []error{}
+
+	for _, err := range v.Run(models...) {
+		var finding *ValidationError
+
+		if !errors.As(err, &finding) {
+			errs = append(errs, err)
+			continue
+		}
+
+		//Findings with conflicting edits are kept, so the conflict is reported
+		if !finding.Fixable && !finding.conflict {
+			continue
+		}
+
+		if _, exists := fixes[finding.Pos.Filename]; !exists {
+			fixes[finding.Pos.Filename] = map[int][]tagFix{}
+		}
+
+		literalFixes, err := addTagFix(fixes[finding.Pos.Filename][finding.Pos.Offset], tagFix{
+			key:         finding.Tag,
+			value:       finding.Value,
+			replacement: finding.Replacement,
+		})
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%v in %v.%v: %v", err, finding.Struct, finding.Field, finding.Pos))
+			continue
+		}
+
+		fixes[finding.Pos.Filename][finding.Pos.Offset] = literalFixes
+	}
+
+	if len(errs) > 0 {
+		return 0, errors.Join(errs...)
+	}
+
+	paths := make([]string, 0, len(fixes))
+
+	for path := range fixes {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+	changed := 0
+
+	for _, path := range paths {
+		before, err := readFile(v.fsys, path)
+
+		if err != nil {
+			return changed, err
+		}
+
+		after, err := fixFile(path, before, fixes[path])
+
+		if err != nil {
+			return changed, err
+		}
+
+		if bytes.Equal(before, after) {
+			continue
+		}
+
+		if err := apply(path, before, after); err != nil {
+			return changed, err
+		}
+
+		changed++
+	}
+
+	return changed, nil
+}
+
+// addTagFix adds a fix for a tag literal, rejecting a different replacement of the same tag.
+func addTagFix(fixes []tagFix, fix tagFix) ([]tagFix, error) {
+	for _, existing := range fixes {
+		if existing.key != fix.key || existing.value != fix.value {
+			continue
+		}
+
+		if existing.replacement != fix.replacement {
+			return fixes, fmt.Errorf("conflicting replacements %q and %q for %v:%q",
+				existing.replacement, fix.replacement, fix.key, fix.value)
+		}
+
+		return fixes, nil
+	}
+
+	return append(fixes, fix), nil
+}
+
+// fixFile applies the fixes to the tag literals found at the given offsets and formats the file.
+func fixFile(path string, src []byte, fixes map[int][]tagFix) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, src, parser.ParseComments)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ast.Inspect(file, func(node ast.Node) bool {
+		field, ok := node.(*ast.Field)
+
+		if !ok || field.Tag == nil {
+			return true
+		}
+
+		literalFixes, exists := fixes[fset.Position(field.Tag.Pos()).Offset]
+
+		if !exists {
+			return true
+		}
+
+		value, fixErr := fixTagLiteral(field.Tag.Value, literalFixes)
+
+		if fixErr != nil {
+			err = fmt.Errorf("%v: %v", fset.Position(field.Tag.Pos()), fixErr)
+			return false
+		}
+
+		field.Tag.Value = value
+
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+
+	if err := format.Node(buf, fset, file); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// fixTagLiteral replaces the values of the fixed keys in a tag literal, leaving the rest of it untouched.
+func fixTagLiteral(literal string, fixes []tagFix) (string, error) {
+	tag, err := unquoteTag(literal)
+
+	if err != nil {
+		return "", err
+	}
+
+	pairs, err := scanTag(tag)
+
+	if err != nil {
+		return "", err
+	}
+
+	//Replace from the end, so the offsets of the pairs before stay valid
+	for i := len(pairs) - 1; i >= 0; i-- {
+		pair := pairs[i]
+
+		for _, fix := range fixes {
+			if fix.key == pair.key && fix.value == pair.value {
+				tag = tag[:pair.valueStart] + strconv.Quote(fix.replacement) + tag[pair.valueEnd:]
+				break
+			}
+		}
+	}
+
+	return quoteTag(tag, literal), nil
+}
@@ -0,0 +1,125 @@
+package validator
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var fixModel = `package models
+
+import "time"
+
+// Customer is a customer.
+type Customer struct {
+	ID        int       ` + "`" + `json:"id" db:"id"` + "`" + `
+	Name      string    ` + "`" + `json:"name_" db:"name_" validate:"required"` + "`" + ` // the name
+	CreatedAt time.Time ` + "`" + `json:"created_at" db:"created_at__"` + "`" + `
+	UpdatedAt time.Time "db:\"updated_at_\""
+}
+
+type Order struct {
+	ID int ` + "`" + `db:"id"` + "`" + `
+}
+`
+
+func Test_testFix(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", fixModel)
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("db")
+
+	changed, err := m.Fix()
+
+	r.NoError(err)
+	r.Equal(1, changed)
+
+	content, err := os.ReadFile(filepath.Join("models", "customer.go"))
+	r.NoError(err)
+
+	expected := strings.NewReplacer(
+		`json:"name_" db:"name_"`, `json:"name_" db:"name"`,
+		`db:"created_at__"`, `db:"created_at"`,
+		`"db:\"updated_at_\""`, `"db:\"updated_at\""`,
+	).Replace(fixModel)
+
+	r.Equal(expected, string(content))
+	r.Empty(m.Run())
+
+	changed, err = m.Fix()
+
+	r.NoError(err)
+	r.Equal(0, changed)
+}
+
+func Test_testFixDryRun(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", fixModel)
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("json")
+
+	diff := &bytes.Buffer{}
+	changed, err := m.FixDryRun(diff)
+
+	r.NoError(err)
+	r.Equal(1, changed)
+
+	path := filepath.Join(os.Getenv("GOPATH"), "src", modelsPath, "customer.go")
+
+	r.Equal("--- "+path+"\n+++ "+path+"\n"+
+		"@@ -5,7 +5,7 @@\n"+
+		" // Customer is a customer.\n"+
+		" type Customer struct {\n"+
+		" \tID        int       `json:\"id\" db:\"id\"`\n"+
+		"-\tName      string    `json:\"name_\" db:\"name_\" validate:\"required\"` // the name\n"+
+		"+\tName      string    `json:\"name\" db:\"name_\" validate:\"required\"` // the name\n"+
+		" \tCreatedAt time.Time `json:\"created_at\" db:\"created_at__\"`\n"+
+		" \tUpdatedAt time.Time \"db:\\\"updated_at_\\\"\"\n"+
+		" }\n", diff.String())
+
+	content, err := os.ReadFile(filepath.Join("models", "customer.go"))
+	r.NoError(err)
+	r.Equal(fixModel, string(content))
+}
+
+func Test_testFixConflict(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", fixModel)
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+
+	for _, replacement := range []string{"a", "b"} {
+		replacement := replacement
+
+		m.AddProcessor("db", func(tag *Tag) []error {
+			if tag.GetValue() != "name_" {
+				return nil
+			}
+
+			return []error{NewFixableError(errors.New("wrong"), replacement)}
+		})
+	}
+
+	changed, err := m.Fix()
+
+	r.Error(err)
+	r.Contains(err.Error(), "conflicting replacements")
+	r.Equal(0, changed)
+
+	content, err := os.ReadFile(filepath.Join("models", "customer.go"))
+	r.NoError(err)
+	r.Equal(fixModel, string(content))
+}
@@ -0,0 +1,114 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// NewValidatorFS creates a validator of the models in the root directory of fsys, e.g. an embed.FS or a fstest.MapFS.
+// Files are listed with fs.ReadDir and read with fs.ReadFile, the positions of the findings hold their paths within fsys.
+// root and the paths given to AddPaths and ValidateFiles are paths of fsys, `.` is its root.
+// Vendor and testdata directories are ignored like on disk, symlinks are always skipped since an fs.FS can't resolve them,
+// whatever SetFollowSymlinks is set to.
+// The files of fsys aren't cached and Fix returns an error, FixDryRun shows the changes instead.
+func NewValidatorFS(fsys fs.FS, root string) Validator {
+	m := NewValidator(path.Clean(root))
+	m.fsys = fsys
+
+	return m
+}
+
+// errReadOnlyFS is returned by Fix for a validator created with NewValidatorFS.
+var errReadOnlyFS = errors.New("Fix can't write the files of an fs.FS, use FixDryRun to see the changes")
+
+// readFile reads a file from fsys, or from disk if it is nil.
+func readFile(fsys fs.FS, name string) ([]byte, error) {
+	if fsys == nil {
+		return os.ReadFile(name)
+	}
+
+	return fs.ReadFile(fsys, name)
+}
+
+// statFile describes a file of fsys, or on disk if it is nil. Symlinks are followed.
+func statFile(fsys fs.FS, name string) (fs.FileInfo, error) {
+	if fsys == nil {
+		return os.Stat(name)
+	}
+
+	return fs.Stat(fsys, name)
+}
+
+// readDir lists a directory of fsys, or on disk if it is nil.
+func readDir(fsys fs.FS, name string) ([]fs.DirEntry, error) {
+	if fsys == nil {
+		return os.ReadDir(name)
+	}
+
+	return fs.ReadDir(fsys, name)
+}
+
+// joinPath joins the elements into a path of fsys, or a path on disk if it is nil.
+func joinPath(fsys fs.FS, elem ...string) string {
+	if fsys == nil {
+		return filepath.Join(elem...)
+	}
+
+	return path.Join(elem...)
+}
+
+// absPath returns the absolute path of a file on disk, the paths of fsys are already rooted in it.
+func absPath(fsys fs.FS, name string) (string, error) {
+	if fsys == nil {
+		return filepath.Abs(name)
+	}
+
+	return path.Clean(name), nil
+}
+
+// relPath returns the name relative to the models folder root, for logging.
+func relPath(fsys fs.FS, root, name string) string {
+	if fsys == nil {
+		rel, _ := filepath.Rel(root, name)
+		return rel
+	}
+
+	if root == "." {
+		return name
+	}
+
+	return strings.TrimPrefix(name, root+"/")
+}
+
+// evalSymlinks returns the path after following the symlinks in it, the paths of an fs.FS are returned as they are.
+func evalSymlinks(fsys fs.FS, name string) (string, error) {
+	if fsys == nil {
+		return filepath.EvalSymlinks(name)
+	}
+
+	return name, nil
+}
+
+// resolveFolder finds the models folder on disk, see resolvePath, or within fsys if it is set.
+func resolveFolder(fsys fs.FS, folder string) (string, error) {
+	if fsys == nil {
+		return resolvePath(folder)
+	}
+
+	folder = path.Clean(folder)
+
+	if !fs.ValidPath(folder) {
+		return folder, fmt.Errorf("Models folder %v not found", folder)
+	}
+
+	if info, err := fs.Stat(fsys, folder); err != nil || !info.IsDir() {
+		return folder, fmt.Errorf("Models folder %v not found", folder)
+	}
+
+	return folder, nil
+}
@@ -0,0 +1,129 @@
+package validator
+
+import (
+	"bytes"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newModelsFS creates the models of the fs tests, along with directories which are ignored and links.
+func newModelsFS() fstest.MapFS {
+	order := []byte("package orders\n\ntype Order struct {\n" +
+		"ID string `db:\"id\"`\n" +
+		"}\n")
+
+	return fstest.MapFS{
+		"models/customer.go": {Data: []byte("package models\n\ntype Customer struct {\n" +
+			"ID int `db:\"id\"`\n" +
+			"Name string `db:\"name_\"`\n" +
+			"}\n")},
+		"models/customer_test.go":        {Data: []byte("package models\n")},
+		"models/customer_link.go":        {Data: []byte("customer.go"), Mode: fs.ModeSymlink},
+		"models/vendor/lib/lib.go":       {Data: []byte("package lib\n\ntype Lib struct {\nID int `db:\"ID\"`\n}\n")},
+		"models/testdata/broken.go":      {Data: []byte("package broken\n\ntype Broken struct {\n")},
+		"models/linked":                  {Data: []byte("../shared/orders"), Mode: fs.ModeSymlink},
+		"models/self":                    {Data: []byte("."), Mode: fs.ModeSymlink},
+		"models/windows_only_windows.go": {Data: order},
+		"shared/orders/order.go":         {Data: order},
+	}
+}
+
+func Test_testValidatorFS(t *testing.T) {
+	r := require.New(t)
+
+	m := NewValidatorFS(newModelsFS(), "models")
+	m.AddDefaultProcessors("db")
+	m.SetRecursive(true)
+	m.SetBuildContext("linux", "amd64", nil)
+
+	result, err := m.Validate()
+	r.NoError(err)
+
+	//Positions hold the paths within the fs, vendor, testdata and the links are skipped
+	r.Len(result.Findings, 1)
+	r.Equal("models/customer.go", result.Findings[0].Pos.Filename)
+	r.Equal(5, result.Findings[0].Pos.Line)
+	r.Equal(1, m.Stats().FilesParsed)
+	r.Len(result.Findings[0].Edits, 1)
+	r.Equal("models/customer.go", result.Findings[0].Edits[0].Pos.Filename)
+
+	skipped := map[string]string{}
+
+	for _, file := range m.FileDiagnostics() {
+		if file.Status == FileSkipped {
+			skipped[file.Path] = file.Reason
+		}
+	}
+
+	r.Equal(map[string]string{
+		"models/customer_link.go":        "symlink",
+		"models/customer_test.go":        "test file",
+		"models/linked":                  "symlink",
+		"models/self":                    "symlink",
+		"models/windows_only_windows.go": "build constraints",
+	}, skipped)
+
+	//The links of an fs.FS can't be resolved, so they are skipped even if they should be followed
+	m.SetFollowSymlinks(true)
+
+	result, err = m.Validate()
+	r.NoError(err)
+	r.Len(result.Findings, 1)
+	r.Equal(1, m.Stats().FilesParsed)
+	r.Len(m.Tags(), 1)
+}
+
+func Test_testValidatorFSPaths(t *testing.T) {
+	r := require.New(t)
+
+	fsys := newModelsFS()
+
+	m := NewValidatorFS(fsys, "./models/")
+	m.AddDefaultProcessors("db")
+	m.AddPaths("shared/orders")
+
+	r.Len(m.Run(), 1)
+	r.Equal(2, m.Stats().FilesParsed)
+	r.Len(m.Tags(), 2)
+
+	//Files are resolved against the models folder of the fs
+	result, err := m.ValidateFiles("customer.go", "shared/orders/order.go", "missing.go")
+	r.NoError(err)
+	r.Len(result.Findings, 1)
+	r.Len(result.Warnings, 1)
+	r.Equal("File missing.go not found", result.Warnings[0].Error())
+
+	m = NewValidatorFS(fsys, "missing")
+	m.AddDefaultProcessors("db")
+
+	_, err = m.Validate()
+	r.EqualError(err, "Models folder missing not found")
+
+	m = NewValidatorFS(fsys, "../models")
+	m.AddDefaultProcessors("db")
+
+	_, err = m.Validate()
+	r.EqualError(err, "Models folder ../models not found")
+}
+
+func Test_testValidatorFSFix(t *testing.T) {
+	r := require.New(t)
+
+	m := NewValidatorFS(fstest.MapFS{"customer.go": {Data: []byte(fixModel)}}, ".")
+	m.AddDefaultProcessors("json")
+
+	diff := &bytes.Buffer{}
+	changed, err := m.FixDryRun(diff)
+
+	r.NoError(err)
+	r.Equal(1, changed)
+	r.Contains(diff.String(), "--- customer.go\n+++ customer.go\n")
+	r.Contains(diff.String(), "+\tName      string    `json:\"name\" db:\"name_\" validate:\"required\"` // the name\n")
+
+	//The files of an fs.FS are not written
+	_, err = m.Fix()
+	r.ErrorIs(err, errReadOnlyFS)
+}
@@ -0,0 +1,222 @@
+package validator
+
+import (
+	"fmt"
+	"sort"
+)
+
+// groupProcessor validates the tags of a group of structs together.
+type groupProcessor struct {
+	name      string
+	group     []string
+	processor func(tags map[string][]*Tag) []error
+}
+
+// AddGroupProcessor adds a processor that validates the tags of the named structs together,
+// e.g. a read and a write model mapped to the same table.
+// It is called once per run after the tag and struct processors, with the tags of the named structs only.
+// The tags are the ones collected for the other processors, or all tags if there are only group and package processors.
+// A struct of the group which isn't found fails the run.
+func (v *Validator) AddGroupProcessor(group []string, processor func(tags map[string][]*Tag) []error) {
+	v.groupProcessors = append(v.groupProcessors, groupProcessor{
+		name:      v.processorLabel(processor),
+		group:     append([]string{}, group...),
+		processor: processor,
+	})
+}
+
+// checkGroups returns an error for the first struct of a group which wasn't collected.
+func (v *Validator) checkGroups() error {
+	for _, g := range v.groupProcessors {
+		for _, name := range g.group {
+			if _, exists := v.tags[name]; !exists {
+				return fmt.Errorf("Struct %v of the group %v not found", name, g.group)
+			}
+		}
+	}
+
+	return nil
+}
+
+// processGroups runs the group processors.
+// Errors which don't point at a tag are attributed to no struct.
+func (v *Validator) processGroups() []error {
+	errs := []error{}
+
+	for _, g := range v.groupProcessors {
+		tags := make(map[string][]*Tag, len(g.group))
+
+		for _, name := range g.group {
+			tags[name] = v.TagsFor(name)
+		}
+
+		errs = append(errs, attributeErrors(wrapErrors(&Tag{}, v.runGroupProcessor(g, tags)), g.name)...)
+		v.stats.ProcessorsRun++
+	}
+
+	return errs
+}
+
+// runGroupProcessor runs a group processor, a panic is returned as an error.
+func (v *Validator) runGroupProcessor(g groupProcessor, tags map[string][]*Tag) (errs []error) {
+	defer v.recoverPanic(&Tag{}, &errs)
+
+	return g.processor(tags)
+}
+
+// AddPackageProcessor adds a processor that validates all collected tags together,
+// e.g. that no two structs map to the same table.
+// It is called once per run with a copy of the tags of all structs, each in field declaration order.
+// Processors run in this order: tag and struct processors struct by struct, then group processors,
+// then package processors, each kind in the order they were added.
+func (v *Validator) AddPackageProcessor(processor func(allTags map[string][]*Tag) []error) {
+	v.AddPackageProcessorNamed(v.processorLabel(processor), processor)
+}
+
+// AddPackageProcessorNamed works like AddPackageProcessor, its findings are counted under the given name in Summary.
+func (v *Validator) AddPackageProcessorNamed(name string, processor func(allTags map[string][]*Tag) []error) {
+	v.packageProcessors = append(v.packageProcessors, packageProcessor{name, processor})
+}
+
+// processPackage runs the package processors.
+// Errors which don't point at a tag are attributed to no struct.
+func (v *Validator) processPackage() []error {
+	errs := []error{}
+
+	for _, processor := range v.packageProcessors {
+		errs = append(errs, attributeErrors(wrapErrors(&Tag{}, v.runPackageProcessor(processor.run, v.Tags())), processor.name)...)
+		v.stats.ProcessorsRun++
+	}
+
+	return errs
+}
+
+// runPackageProcessor runs a package processor, a panic is returned as an error.
+func (v *Validator) runPackageProcessor(processor func(allTags map[string][]*Tag) []error, tags map[string][]*Tag) (errs []error) {
+	defer v.recoverPanic(&Tag{}, &errs)
+
+	return processor(tags)
+}
+
+// NewMirrorProcessor creates a group processor reporting the tag values one of the structs has and the other one is missing.
+// Values are compared per tag name, e.g. every db column of a must be a db column of b and the other way around.
+func NewMirrorProcessor(a, b string) func(tags map[string][]*Tag) []error {
+	return func(tags map[string][]*Tag) []error {
+		return append(mirrorMissing(tags[a], tags[b], b), mirrorMissing(tags[b], tags[a], a)...)
+	}
+}
+
+// mirrorMissing reports the tags of from whose name and value aren't found in to.
+func mirrorMissing(from, to []*Tag, toName string) []error {
+	errs := []error{}
+	values := make(map[[2]string]bool, len(to))
+
+	for _, t := range to {
+		values[[2]string{t.GetName(), t.GetValue()}] = true
+	}
+
+	for _, t := range from {
+		if values[[2]string{t.GetName(), t.GetValue()}] {
+			continue
+		}
+
+		errs = append(errs, newValidationError(t, fmt.Errorf("Tag value %v in %v.%v is missing from %v",
+			t.GetValue(), t.GetStructName(), t.GetName(), toName)))
+	}
+
+	return errs
+}
+
+// NewMirrorTagsProcessor creates a package processor comparing the tags of struct pairs which must mirror each other field for field,
+// e.g. a DTO generated from a domain struct:
+//
+//	m.AddPackageProcessor(NewMirrorTagsProcessor("json", map[string]string{"Customer": "CustomerDTO"}))
+//
+// Fields are matched by name, a field whose tag has no counterpart in the other struct or holds another value is reported along with both structs.
+// A differing value can be fixed to the one of the domain struct. Fields either struct leaves out with its ignore directive are skipped,
+// see IgnoreDirective, and a pair whose structs weren't both collected is reported once. See NewMirrorProcessor to compare the values regardless of the fields.
+func NewMirrorTagsProcessor(tagName string, pairs map[string]string) func(allTags map[string][]*Tag) []error {
+	domains := make([]string, 0, len(pairs))
+
+	for domain := range pairs {
+		domains = append(domains, domain)
+	}
+
+	sort.Strings(domains)
+
+	return func(allTags map[string][]*Tag) []error {
+		errs := []error{}
+
+		for _, domain := range domains {
+			mirror := pairs[domain]
+			domainTags, mirrorTags := allTags[domain], allTags[mirror]
+
+			if len(domainTags) == 0 || len(mirrorTags) == 0 {
+				errs = append(errs, &ValidationError{
+					Struct:  domain,
+					Message: fmt.Sprintf("Structs %v and %v mirror each other, but both must be collected", domain, mirror),
+				})
+
+				continue
+			}
+
+			domainFields, domainOrder := mirroredFields(domainTags, tagName)
+			mirrorFields, mirrorOrder := mirroredFields(mirrorTags, tagName)
+			domainIgnore, _ := domainTags[0].StructDirective(IgnoreDirective)
+			mirrorIgnore, _ := mirrorTags[0].StructDirective(IgnoreDirective)
+
+			for _, field := range domainOrder {
+				if ignoredBy(mirrorIgnore, field) {
+					continue
+				}
+
+				t := domainFields[field]
+				m, exists := mirrorFields[field]
+
+				if !exists {
+					errs = append(errs, newValidationError(t, fmt.Errorf("Tag %v of %v.%v isn't mirrored by %v, it has no field %v with the tag",
+						tagName, domain, field, mirror, field)))
+
+					continue
+				}
+
+				if m.GetValue() != t.GetValue() {
+					err := fmt.Errorf("Tag %v of %v.%v is %q, it must mirror %q of %v.%v",
+						tagName, mirror, field, m.GetValue(), t.GetValue(), domain, field)
+					errs = append(errs, newValidationError(m, NewFixableError(err, t.GetValue())))
+				}
+			}
+
+			for _, field := range mirrorOrder {
+				if _, exists := domainFields[field]; exists || ignoredBy(domainIgnore, field) {
+					continue
+				}
+
+				errs = append(errs, newValidationError(mirrorFields[field], fmt.Errorf("Tag %v of %v.%v mirrors nothing, %v has no field %v with the tag",
+					tagName, mirror, field, domain, field)))
+			}
+		}
+
+		return errs
+	}
+}
+
+// mirroredFields returns the tags of the given name by field, along with the fields in declaration order.
+// A field holding the tag twice keeps its first value, like reflect.StructTag does.
+func mirroredFields(tags []*Tag, tagName string) (map[string]*Tag, []string) {
+	fields := map[string]*Tag{}
+	order := []string{}
+
+	for _, t := range tags {
+		if t.GetName() != tagName {
+			continue
+		}
+
+		if _, exists := fields[t.GetFieldName()]; !exists {
+			fields[t.GetFieldName()] = t
+			order = append(order, t.GetFieldName())
+		}
+	}
+
+	return fields, order
+}
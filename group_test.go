@@ -0,0 +1,235 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_testMirrorProcessor(t *testing.T) {
+	r := require.New(t)
+
+	createFile("order.go", `package models
+
+type Order struct {
+	ID        int    `+"`"+`db:"id" json:"id"`+"`"+`
+	Total     int    `+"`"+`db:"total" json:"total"`+"`"+`
+	CreatedAt string `+"`"+`db:"created_at"`+"`"+`
+}
+
+type OrderView struct {
+	ID       int    `+"`"+`db:"id" json:"order_id"`+"`"+`
+	Total    int    `+"`"+`db:"total" json:"total"`+"`"+`
+	Customer string `+"`"+`db:"customer"`+"`"+`
+}
+`)
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddProcessor("db", func(tag *Tag) []error { return nil })
+	m.AddGroupProcessor([]string{"Order", "OrderView"}, NewMirrorProcessor("Order", "OrderView"))
+
+	messages := []string{}
+
+	for _, err := range m.Run() {
+		messages = append(messages, err.Error())
+	}
+
+	//Only the db tags are collected for the other processors
+	r.Equal([]string{
+		"Tag value created_at in Order.db is missing from OrderView",
+		"Tag value customer in OrderView.db is missing from Order",
+	}, messages)
+
+	//A group processor alone sees all tags
+	m = NewValidator(modelsPath)
+	m.AddGroupProcessor([]string{"Order", "OrderView"}, func(tags map[string][]*Tag) []error {
+		r.Len(tags, 2)
+		r.Len(tags["Order"], 5)
+		r.Len(tags["OrderView"], 5)
+
+		return []error{errors.New("group error")}
+	})
+
+	result, err := m.Validate()
+
+	r.NoError(err)
+	r.Len(result.Findings, 1)
+	r.Equal("group error", result.Findings[0].Error())
+	r.Empty(result.Findings[0].Struct)
+
+	m.AddGroupProcessor([]string{"Order", "OrderRead"}, NewMirrorProcessor("Order", "OrderRead"))
+
+	_, err = m.Validate()
+
+	r.EqualError(err, "Struct OrderRead of the group [Order OrderRead] not found")
+}
+
+func Test_testPackageProcessor(t *testing.T) {
+	r := require.New(t)
+
+	createFile("order.go", `package models
+
+type Order struct {
+	ID int `+"`"+`db:"id" table:"orders"`+"`"+`
+}
+
+type OrderView struct {
+	ID int `+"`"+`db:"id" table:"orders"`+"`"+`
+}
+`)
+	defer os.RemoveAll("./models")
+
+	calls := []string{}
+
+	m := NewValidator(modelsPath)
+	m.AddPackageProcessor(func(allTags map[string][]*Tag) []error {
+		calls = append(calls, "package")
+		tables := map[string]string{}
+		errs := []error{}
+
+		for _, structName := range []string{"Order", "OrderView"} {
+			for _, tag := range allTags[structName] {
+				if tag.GetName() != "table" {
+					continue
+				}
+
+				if holder, exists := tables[tag.GetValue()]; exists {
+					errs = append(errs, fmt.Errorf("Table %v is mapped by %v and %v", tag.GetValue(), holder, structName))
+				}
+
+				tables[tag.GetValue()] = structName
+			}
+		}
+
+		//The map is a copy
+		delete(allTags, "Order")
+
+		return errs
+	})
+	m.AddGroupProcessor([]string{"Order"}, func(tags map[string][]*Tag) []error {
+		calls = append(calls, "group")
+		return nil
+	})
+	m.AddStructProcessor("db", func(s *StructInfo) []error {
+		calls = append(calls, "struct")
+		return nil
+	})
+	m.AddProcessor("table", func(tag *Tag) []error {
+		calls = append(calls, "tag")
+		return nil
+	})
+	m.SetAllowDuplicates(true)
+
+	report := m.RunReport()
+
+	r.Equal([]string{"tag", "struct", "tag", "struct", "group", "package"}, calls)
+	r.Len(report.Findings, 1)
+	r.Equal("Table orders is mapped by Order and OrderView", report.Findings[0].Error())
+	r.Empty(report.Findings[0].Struct)
+	r.Empty(report.Findings[0].Field)
+	r.Len(m.TagsFor("Order"), 2)
+}
+
+func Test_testMirrorTagsProcessor(t *testing.T) {
+	r := require.New(t)
+
+	//CustomerDTO drifted, Email was renamed and Phone was added without its domain field
+	createFile("customer.go", `package models
+
+// tagvalidator:ignore=Password
+type Customer struct {
+	ID       int    `+"`"+`json:"id"`+"`"+`
+	Name     string `+"`"+`json:"name"`+"`"+`
+	Email    string `+"`"+`json:"email"`+"`"+`
+	Address  string `+"`"+`json:"address"`+"`"+`
+	Password string `+"`"+`json:"password"`+"`"+`
+}
+
+// tagvalidator:ignore=Cache*
+type CustomerDTO struct {
+	ID        int    `+"`"+`json:"id"`+"`"+`
+	Name      string `+"`"+`json:"name"`+"`"+`
+	Email     string `+"`"+`json:"mail"`+"`"+`
+	Phone     string `+"`"+`json:"phone"`+"`"+`
+	CachedAt  string `+"`"+`json:"cached_at"`+"`"+`
+}
+
+type Order struct {
+	ID int `+"`"+`json:"id"`+"`"+`
+}
+
+type OrderDTO struct {
+	ID int `+"`"+`json:"id"`+"`"+`
+}
+`)
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddPackageProcessor(NewMirrorTagsProcessor("json", map[string]string{
+		"Customer": "CustomerDTO",
+		"Order":    "OrderDTO",
+		"Invoice":  "InvoiceDTO",
+	}))
+
+	result, err := m.Validate()
+
+	r.NoError(err)
+
+	messages := map[string]*ValidationError{}
+
+	for _, finding := range result.Findings {
+		messages[finding.Message] = finding
+	}
+
+	r.Len(messages, 4)
+
+	drifted := messages[`Tag json of CustomerDTO.Email is "mail", it must mirror "email" of Customer.Email`]
+	r.NotNil(drifted)
+	r.Equal("CustomerDTO", drifted.Struct)
+	r.Equal("Email", drifted.Field)
+	r.Equal(16, drifted.Pos.Line)
+	r.True(drifted.Fixable)
+	r.Equal("email", drifted.Replacement)
+	r.Equal("NewMirrorTagsProcessor", drifted.Processor)
+
+	missing := messages["Tag json of Customer.Address isn't mirrored by CustomerDTO, it has no field Address with the tag"]
+	r.NotNil(missing)
+	r.Equal("Customer", missing.Struct)
+	r.Equal(8, missing.Pos.Line)
+
+	extra := messages["Tag json of CustomerDTO.Phone mirrors nothing, Customer has no field Phone with the tag"]
+	r.NotNil(extra)
+	r.Equal("Phone", extra.Field)
+
+	r.NotNil(messages["Structs Invoice and InvoiceDTO mirror each other, but both must be collected"])
+
+	//Ignored fields aren't collected for any processor
+	r.Len(m.TagsFor("Customer"), 4)
+	r.Equal(2, result.Stats.FieldsExcluded)
+
+	m = NewValidator("unused")
+
+	r.NoError(m.LoadConfig(strings.NewReader(`
+path: ` + modelsPath + `
+processors:
+  - name: mirror-tags
+    tags: [json]
+    args:
+      pairs: {Customer: CustomerDTO}
+`)))
+
+	result, err = m.Validate()
+
+	r.NoError(err)
+	r.Equal(map[string]int{"mirror-tags": 3}, m.Summary())
+
+	r.EqualError(m.LoadConfig(strings.NewReader("processors:\n  - name: mirror-tags\n    args: {pairs: {}}\n")),
+		"line 2: processor mirror-tags: The mirror needs pairs of struct names")
+	r.EqualError(m.LoadConfig(strings.NewReader("processors:\n  - name: mirror-tags\n    args: {pairs: {A: B}}\n")),
+		"line 2: processor mirror-tags: The mirror needs the tags to compare")
+}
@@ -0,0 +1,64 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// jsonOptions are the options encoding/json understands.
+var jsonOptions = map[string]bool{
+	"omitempty": true,
+	"omitzero":  true,
+	"string":    true,
+}
+
+// The names the findings of the json processors are counted under in Summary, see AddJSONProcessors.
+const (
+	JSONOptionsProcessor    = "json-options"
+	JSONUnexportedProcessor = "json-unexported"
+)
+
+// AddJSONProcessors adds the default processors for json tags along with a check of their options
+// and a warning about json tags on unexported fields.
+// The name of a json tag may be left empty to inherit the field name, e.g. `json:",omitempty"`, so it is allowed.
+func (v *Validator) AddJSONProcessors() {
+	v.AllowEmptyValue("json")
+	v.AddDefaultProcessors("json")
+	v.AddProcessorNamed("json", JSONOptionsProcessor, checkJSONOptions)
+	v.AddProcessorNamed("json", JSONUnexportedProcessor, checkJSONUnexported)
+}
+
+// checkJSONUnexported warns about json tags of unexported fields, encoding/json ignores the fields along with their tags.
+// The tags aren't collected at all if they are skipped with SetSkipUnexported.
+func checkJSONUnexported(tag *Tag) []error {
+	errs := []error{}
+
+	if !tag.IsExported() {
+		errs = append(errs, NewWarning(fmt.Errorf("Tag json on the unexported field %v.%v has no effect, encoding/json ignores the field",
+			tag.GetStructName(), tag.GetFieldName())))
+	}
+
+	return errs
+}
+
+// checkJSONOptions reports the options of a json tag which encoding/json ignores, e.g. a misspelled `omitemtpy`.
+func checkJSONOptions(tag *Tag) []error {
+	errs := []error{}
+	options := strings.Split(tag.GetValue(), ",")[1:]
+
+	for _, option := range options {
+		if len(option) == 0 || jsonOptions[option] {
+			continue
+		}
+
+		err := fmt.Errorf("Unknown json option %v in %v.%v", option, tag.GetStructName(), tag.GetFieldName())
+
+		if known, found := nearest(option, jsonOptions, 2); found {
+			err = NewErrorWithSuggestion(err, known)
+		}
+
+		errs = append(errs, err)
+	}
+
+	return errs
+}
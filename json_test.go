@@ -0,0 +1,122 @@
+package validator
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_testAllowEmptyValue(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", `package models
+
+type Customer struct {
+	ID      int    `+"`"+`json:",omitempty" db:",omitempty"`+"`"+`
+	Name    string `+"`"+`json:"" db:""`+"`"+`
+	Surname string `+"`"+`json:"surname,omitemtpy" db:"surname"`+"`"+`
+}
+`)
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("json", "db")
+
+	messages := []string{}
+
+	for _, err := range m.Run() {
+		messages = append(messages, err.Error())
+	}
+
+	r.ElementsMatch([]string{
+		"Tag name cannot be empty Customer.json, only options are given",
+		"Tag name cannot be empty Customer.db, only options are given",
+		"Tag cannot be empty Customer.json",
+		"Tag cannot be empty Customer.db",
+	}, messages)
+
+	//The json processors relax the rule for json only
+	m = NewValidator(modelsPath)
+	m.AddDefaultProcessors("db")
+	m.AddJSONProcessors()
+
+	messages = []string{}
+
+	for _, err := range m.Run() {
+		messages = append(messages, err.Error())
+	}
+
+	r.ElementsMatch([]string{
+		"Tag name cannot be empty Customer.db, only options are given",
+		"Tag cannot be empty Customer.json",
+		"Tag cannot be empty Customer.db",
+		"Unknown json option omitemtpy in Customer.Surname (suggested: omitempty)",
+	}, messages)
+	r.Equal(1, m.Summary()[JSONOptionsProcessor])
+}
+
+func Test_testSkipUnexported(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", `package models
+
+type Customer struct {
+	ID       int    `+"`"+`json:"id" db:"id"`+"`"+`
+	name     string `+"`"+`json:"name" db:"name"`+"`"+`
+	password string `+"`"+`json:"id" db:"id"`+"`"+`
+}
+`)
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("db")
+	m.AddJSONProcessors()
+
+	result, err := m.Validate()
+	r.NoError(err)
+
+	messages := []string{}
+	severities := map[string]Severity{}
+
+	for _, finding := range result.Findings {
+		messages = append(messages, finding.Error())
+		severities[finding.Processor+" "+finding.Field] = finding.Severity
+	}
+
+	r.ElementsMatch([]string{
+		"Tag json on the unexported field Customer.name has no effect, encoding/json ignores the field",
+		"Tag json on the unexported field Customer.password has no effect, encoding/json ignores the field",
+		"Duplicate tag value id in Customer.json (suggested: rename it, the value is held by Customer.ID)",
+		"Duplicate tag value id in Customer.db (suggested: rename it, the value is held by Customer.ID)",
+	}, messages)
+
+	//The tags on unexported fields are only warned about, they don't fail the run
+	r.Equal(map[string]Severity{
+		JSONUnexportedProcessor + " name":     SeverityWarning,
+		JSONUnexportedProcessor + " password": SeverityWarning,
+		DuplicatesProcessor + " password":     SeverityError,
+	}, severities)
+	r.Equal(map[string]int{JSONUnexportedProcessor: 2, DuplicatesProcessor: 2}, m.Summary())
+	r.Equal(map[Severity]int{SeverityWarning: 2, SeverityError: 2}, result.Counts().BySeverity)
+
+	tags := m.TagsFor("Customer")
+	r.True(tags[0].IsExported())
+	r.False(tags[2].IsExported())
+
+	//The db layer reads unexported fields, so only their json tags are left out
+	m.SetSkipUnexported("json")
+
+	messages = []string{}
+
+	for _, err := range m.Run() {
+		messages = append(messages, err.Error())
+	}
+
+	r.Equal([]string{"Duplicate tag value id in Customer.db (suggested: rename it, the value is held by Customer.ID)"}, messages)
+	r.Len(m.TagsFor("Customer"), 4)
+
+	m.SetSkipUnexported(AllTags)
+	r.Empty(m.Run())
+	r.Len(m.TagsFor("Customer"), 2)
+}
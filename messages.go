@@ -0,0 +1,116 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// MessageKind identifies a message of the default processors and checks, see SetMessageTemplate.
+type MessageKind string
+
+const (
+	// MessageInvalidSymbols reports characters of a value outside of the allowed charset.
+	MessageInvalidSymbols MessageKind = "invalid-symbols"
+	// MessageTrailingChar reports a value which doesn't end on a letter or a digit.
+	MessageTrailingChar MessageKind = "trailing-char"
+	// MessageEmptyTag reports an empty value.
+	MessageEmptyTag MessageKind = "empty-tag"
+	// MessageEmptyName reports a value with options only, e.g. `db:",omitempty"`.
+	MessageEmptyName MessageKind = "empty-name"
+	// MessageDuplicate reports a value held by another field of the struct.
+	MessageDuplicate MessageKind = "duplicate"
+	// MessageMissingTag reports a field without a required tag.
+	MessageMissingTag MessageKind = "missing-tag"
+)
+
+// MessageData holds the fields message templates can use, e.g. `{{.Struct}}.{{.Field}}`.
+type MessageData struct {
+	Struct string
+	Field  string
+	Tag    string
+	Value  string
+	//Match is the offending part of the value, for invalid-symbols and trailing-char
+	Match string
+	//Charset is the character class values are checked against, for invalid-symbols and trailing-char
+	Charset string
+}
+
+// defaultMessages are the templates of the messages which weren't overridden.
+var defaultMessages = messageTemplates{
+	MessageInvalidSymbols: template.Must(newMessageTemplate(MessageInvalidSymbols, "Invalid symboles {{.Match}} in {{.Struct}}.{{.Tag}}.{{.Value}}, charset [{{.Charset}}]")),
+	MessageTrailingChar:   template.Must(newMessageTemplate(MessageTrailingChar, "Tag cannot end on {{.Match}} in  {{.Struct}}.{{.Tag}}.{{.Value}}, charset [{{.Charset}}]")),
+	MessageEmptyTag:       template.Must(newMessageTemplate(MessageEmptyTag, "Tag cannot be empty {{.Struct}}.{{.Tag}}")),
+	MessageEmptyName:      template.Must(newMessageTemplate(MessageEmptyName, "Tag name cannot be empty {{.Struct}}.{{.Tag}}, only options are given")),
+	MessageDuplicate:      template.Must(newMessageTemplate(MessageDuplicate, "Duplicate tag value {{.Value}} in {{.Struct}}.{{.Tag}}")),
+	MessageMissingTag:     template.Must(newMessageTemplate(MessageMissingTag, "Missing tag {{.Tag}} in {{.Struct}}.{{.Field}}")),
+}
+
+// messageTemplates are the message templates by kind.
+type messageTemplates map[MessageKind]*template.Template
+
+// newMessageTemplate parses a message template and renders it once, so unknown fields are reported right away.
+func newMessageTemplate(kind MessageKind, text string) (*template.Template, error) {
+	tmpl, err := template.New(string(kind)).Option("missingkey=error").Parse(text)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tmpl.Execute(&strings.Builder{}, MessageData{}); err != nil {
+		return nil, err
+	}
+
+	return tmpl, nil
+}
+
+// SetMessageTemplate overrides the message of the given kind, e.g. to translate it.
+// The template uses the text/template syntax with the fields of MessageData, unknown fields are an error.
+// Findings keep their raw fields and their kind, whatever the message says.
+func (v *Validator) SetMessageTemplate(kind MessageKind, text string) error {
+	if _, exists := defaultMessages[kind]; !exists {
+		return fmt.Errorf("Unknown message kind %v", kind)
+	}
+
+	tmpl, err := newMessageTemplate(kind, text)
+
+	if err != nil {
+		return fmt.Errorf("Invalid %v message template: %w", kind, err)
+	}
+
+	//Copied on write, so runs in progress keep the templates they started with
+	messages := make(messageTemplates, len(v.messages)+1)
+
+	for k, t := range v.messages {
+		messages[k] = t
+	}
+
+	messages[kind] = tmpl
+	v.messages = messages
+
+	return nil
+}
+
+// newError renders the message of the given kind into a finding of that kind.
+func (m messageTemplates) newError(kind MessageKind, data MessageData) *ValidationError {
+	tmpl, exists := m[kind]
+
+	if !exists {
+		tmpl = defaultMessages[kind]
+	}
+
+	msg := &strings.Builder{}
+
+	//The templates were rendered once already, should it fail anyway the default message is used
+	if err := tmpl.Execute(msg, data); err != nil {
+		msg.Reset()
+		defaultMessages[kind].Execute(msg, data)
+	}
+
+	return &ValidationError{
+		Message: msg.String(),
+		Kind:    kind,
+		err:     errors.New(msg.String()),
+	}
+}
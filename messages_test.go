@@ -0,0 +1,99 @@
+package validator
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_testMessageTemplates(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", `package models
+
+type Customer struct {
+	ID    int    `+"`"+`db:"id"`+"`"+`
+	Name  string `+"`"+`db:"id"`+"`"+`
+	Email string `+"`"+`db:"e-mail"`+"`"+`
+	Phone string `+"`"+`db:""`+"`"+`
+	Note  string
+}
+`)
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("db")
+	m.RequireTag("db")
+
+	r.NoError(m.SetMessageTemplate(MessageInvalidSymbols, "{{.Struct}}.{{.Field}}: {{.Match}} nicht erlaubt in {{.Value}}"))
+	r.NoError(m.SetMessageTemplate(MessageDuplicate, "{{.Struct}}.{{.Field}}: {{.Value}} doppelt"))
+	r.NoError(m.SetMessageTemplate(MessageEmptyTag, "{{.Struct}}.{{.Field}}: {{.Tag}} leer"))
+	r.NoError(m.SetMessageTemplate(MessageMissingTag, "{{.Struct}}.{{.Field}}: {{.Tag}} fehlt"))
+
+	result, err := m.Validate()
+
+	r.NoError(err)
+
+	messages := map[MessageKind]string{}
+
+	for _, finding := range result.Findings {
+		messages[finding.Kind] = finding.Message
+	}
+
+	r.Equal(map[MessageKind]string{
+		MessageInvalidSymbols: "Customer.Email: - nicht erlaubt in e-mail",
+		MessageDuplicate:      "Customer.Name: id doppelt",
+		MessageEmptyTag:       "Customer.Phone: db leer",
+		MessageMissingTag:     "Customer.Note: db fehlt",
+	}, messages)
+
+	//The structured fields don't depend on the message
+	for _, finding := range result.Findings {
+		if finding.Kind == MessageInvalidSymbols {
+			r.Equal("Email", finding.Field)
+			r.Equal("db", finding.Tag)
+			r.Equal("e-mail", finding.Value)
+			r.Equal("e_mail", finding.Suggestion)
+			r.Equal(6, finding.Pos.Line)
+		}
+	}
+}
+
+func Test_testMessageTemplateDefaults(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", `package models
+
+type Customer struct {
+	Email string `+"`"+`db:"email_"`+"`"+`
+}
+`)
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("db")
+	r.NoError(m.SetMessageTemplate(MessageDuplicate, "{{.Value}}"))
+
+	result, err := m.Validate()
+
+	r.NoError(err)
+	r.Len(result.Findings, 1)
+	r.Equal(MessageTrailingChar, result.Findings[0].Kind)
+	r.Equal("Tag cannot end on _ in  Customer.db.email_, charset [a-z0-9_, ]", result.Findings[0].Message)
+}
+
+func Test_testMessageTemplateInvalid(t *testing.T) {
+	r := require.New(t)
+
+	m := NewValidator(modelsPath)
+
+	r.Error(m.SetMessageTemplate("unknown", "{{.Struct}}"))
+	r.Error(m.SetMessageTemplate(MessageDuplicate, "{{.Struct"))
+
+	err := m.SetMessageTemplate(MessageDuplicate, "{{.Column}}")
+	r.Error(err)
+	r.Contains(err.Error(), "Column")
+
+	r.NoError(m.SetMessageTemplate(MessageDuplicate, "{{.Struct | printf \"%q\"}}"))
+}
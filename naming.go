@@ -0,0 +1,105 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// SnakeCase converts a Go field name to snake_case.
+// Runs of capitals are kept together as acronyms, e.g. CreatedAt becomes created_at, ID id and APIKey api_key.
+func SnakeCase(name string) string {
+	return strings.ToLower(strings.Join(splitWords(name, nil), "_"))
+}
+
+// SnakeCaseWithAcronyms returns a snake_case transform which treats the given words as one,
+// e.g. with "OAuth" the field OAuthToken becomes oauth_token instead of o_auth_token.
+func SnakeCaseWithAcronyms(acronyms ...string) func(name string) string {
+	return func(name string) string {
+		return strings.ToLower(strings.Join(splitWords(name, acronyms), "_"))
+	}
+}
+
+// splitWords splits a Go identifier into its words, underscores separate words as well.
+func splitWords(name string, acronyms []string) []string {
+	runes := []rune(name)
+	words := []string{}
+	start := 0
+
+	flush := func(end int) {
+		if start < end {
+			words = append(words, string(runes[start:end]))
+		}
+
+		start = end
+	}
+
+Loop:
+	for i := 0; i < len(runes); {
+		for _, acronym := range acronyms {
+			if len(acronym) > 0 && strings.HasPrefix(string(runes[i:]), acronym) {
+				flush(i)
+				i += len([]rune(acronym))
+				flush(i)
+				continue Loop
+			}
+		}
+
+		r := runes[i]
+
+		switch {
+		case r == '_':
+			flush(i)
+			start = i + 1
+		case i > start && unicode.IsUpper(r):
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+
+			//A capital starts a word after a lowercase letter or a digit,
+			//or when it ends a run of capitals and is followed by lowercase, as in APIKey
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				flush(i)
+			}
+		}
+
+		i++
+	}
+
+	flush(len(runes))
+
+	return words
+}
+
+// NewFieldNameConsistencyProcessor returns a processor checking that the primary value of a tag,
+// the part before the first comma, equals the transformed name of its field.
+// SnakeCase is used if transform is nil. Tags with an empty primary value or the value `-` are skipped.
+func NewFieldNameConsistencyProcessor(transform func(fieldName string) string) func(tag *Tag) []error {
+	if transform == nil {
+		transform = SnakeCase
+	}
+
+	return func(tag *Tag) []error {
+		primary, options, _ := strings.Cut(tag.GetValue(), ",")
+
+		if len(primary) == 0 || tag.GetValue() == SkipTag || len(tag.GetFieldName()) == 0 {
+			return nil
+		}
+
+		expected := transform(tag.GetFieldName())
+
+		if primary == expected {
+			return nil
+		}
+
+		replacement := expected
+
+		if strings.Contains(tag.GetValue(), ",") {
+			replacement = expected + "," + options
+		}
+
+		err := fmt.Errorf("Tag value %v does not match the field name %v in %v.%v, expected %v",
+			primary, tag.GetFieldName(), tag.GetStructName(), tag.GetName(), expected)
+
+		return []error{NewFixableError(err, replacement)}
+	}
+}
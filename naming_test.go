@@ -0,0 +1,71 @@
+package validator
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_testSnakeCase(t *testing.T) {
+	r := require.New(t)
+
+	cases := map[string]string{
+		"ID":               "id",
+		"CreatedAt":        "created_at",
+		"APIKey":           "api_key",
+		"UserID":           "user_id",
+		"HTTPServerURL":    "http_server_url",
+		"Address2":         "address2",
+		"V2Name":           "v2_name",
+		"XXX_unrecognized": "xxx_unrecognized",
+		"name":             "name",
+		"OAuthToken":       "o_auth_token",
+	}
+
+	for name, expected := range cases {
+		r.Equal(expected, SnakeCase(name), name)
+	}
+
+	transform := SnakeCaseWithAcronyms("OAuth")
+
+	r.Equal("oauth_token", transform("OAuthToken"))
+	r.Equal("user_oauth", transform("UserOAuth"))
+	r.Equal("created_at", transform("CreatedAt"))
+}
+
+func Test_testFieldNameConsistencyProcessor(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", `package models
+
+type Customer struct {
+	ID        int    `+"`"+`db:"id"`+"`"+`
+	APIKey    string `+"`"+`db:"api_key,readonly"`+"`"+`
+	CreatedAt string `+"`"+`db:"created"`+"`"+`
+	UpdatedAt string `+"`"+`db:"updatedat,omitempty"`+"`"+`
+	Skipped   string `+"`"+`db:",omitempty"`+"`"+`
+}
+`)
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddProcessor("db", NewFieldNameConsistencyProcessor(nil))
+
+	messages := []string{}
+
+	for _, err := range m.Run() {
+		messages = append(messages, err.Error())
+	}
+
+	r.ElementsMatch([]string{
+		"Tag value created does not match the field name CreatedAt in Customer.db, expected created_at (suggested: created_at)",
+		"Tag value updatedat does not match the field name UpdatedAt in Customer.db, expected updated_at (suggested: updated_at,omitempty)",
+	}, messages)
+
+	changed, err := m.Fix()
+
+	r.NoError(err)
+	r.Equal(1, changed)
+	r.Empty(m.Run())
+}
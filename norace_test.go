@@ -0,0 +1,6 @@
+//go:build !race
+
+package validator
+
+// raceEnabled tells whether the tests are built with the race detector, the plugins they build must be as well.
+const raceEnabled = false
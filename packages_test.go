@@ -0,0 +1,168 @@
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// createPackages creates a billing and a reporting package, both declaring an Invoice.
+func createPackages(r *require.Assertions) {
+	r.NoError(os.MkdirAll(filepath.Join("models", "billing"), 0755))
+	r.NoError(os.MkdirAll(filepath.Join("models", "reporting"), 0755))
+
+	createFile(filepath.Join("billing", "invoice.go"), "package billing\n\ntype Invoice struct {\n"+
+		"ID int `db:\"id\"`\n"+
+		"Total int `db:\"total\"`\n"+
+		"Customer string `db:\"customer\"`\n"+
+		"}\n")
+	createFile(filepath.Join("reporting", "invoice.go"), "package reporting\n\ntype Invoice struct {\n"+
+		"ID int `db:\"id\"`\n"+
+		"Total int `db:\"total\"`\n"+
+		"Sum int `db:\"total\"`\n"+
+		"}\n\ntype Summary struct {\n"+
+		"ID int `db:\"id\"`\n"+
+		"}\n")
+}
+
+func Test_testValidatePackages(t *testing.T) {
+	r := require.New(t)
+
+	createPackages(r)
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("db")
+	m.SetRecursive(true)
+
+	structs := map[string]int{}
+	m.AddStructProcessor("db", func(s *StructInfo) []error {
+		structs[s.Name] = len(s.Tags)
+		return nil
+	})
+
+	result, err := m.Validate()
+	r.NoError(err)
+
+	//Only the duplicate within reporting.Invoice is reported, the package is part of the name
+	r.Len(result.Findings, 1)
+	r.Equal("reporting.Invoice", result.Findings[0].Struct)
+	r.Equal("Sum", result.Findings[0].Field)
+	r.Contains(result.Findings[0].Message, "reporting.Invoice")
+	r.Equal(map[string]int{"billing.Invoice": 3, "reporting.Invoice": 3, "reporting.Summary": 1}, structs)
+
+	tags := m.Tags()
+	names := []string{}
+
+	for name := range tags {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	r.Equal([]string{"billing.Invoice", "reporting.Invoice", "reporting.Summary"}, names)
+	r.Equal("billing", tags["billing.Invoice"][0].GetPackage())
+	r.Equal("reporting", tags["reporting.Invoice"][0].GetPackage())
+
+	//Patterns match the qualified and the plain names
+	m.IncludeStructs("Invoice")
+	m.ExcludeStructs("reporting.*")
+
+	result, err = m.Validate()
+	r.NoError(err)
+	r.Empty(result.Findings)
+	r.Len(m.Tags(), 1)
+	r.Len(m.TagsFor("billing.Invoice"), 3)
+}
+
+func Test_testValidateQualifiedModels(t *testing.T) {
+	r := require.New(t)
+
+	createPackages(r)
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("db")
+	m.SetRecursive(true)
+
+	//The plain model matches the files of both packages, the qualified one only the file of its package
+	result, err := m.Validate("invoice")
+	r.NoError(err)
+	r.Len(result.Findings, 1)
+	r.Len(m.Tags(), 3)
+
+	result, err = m.Validate("billing.invoice")
+	r.NoError(err)
+	r.Empty(result.Findings)
+	r.Len(m.Tags(), 1)
+
+	//A single package keeps the plain names
+	r.Len(m.TagsFor("Invoice"), 3)
+	r.Equal("billing", m.TagsFor("Invoice")[0].GetPackage())
+}
+
+func Test_testValidateSameNamePackages(t *testing.T) {
+	r := require.New(t)
+
+	//Both packages are named models, only their paths tell them apart
+	for _, version := range []string{"v1", "v2"} {
+		r.NoError(os.MkdirAll(filepath.Join("models", "api", version, "models"), 0755))
+	}
+
+	createFile(filepath.Join("api", "v1", "models", "user.go"), "package models\n\ntype User struct {\n"+
+		"ID int `db:\"id\"`\n"+
+		"Name string `db:\"name\"`\n"+
+		"}\n")
+	createFile(filepath.Join("api", "v2", "models", "user.go"), "package models\n\ntype User struct {\n"+
+		"ID int `db:\"id\"`\n"+
+		"Login string `db:\"name\"`\n"+
+		"Name string `db:\"name\"`\n"+
+		"}\n")
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("db")
+	m.SetRecursive(true)
+
+	structs := map[string]int{}
+	m.AddStructProcessor("db", func(s *StructInfo) []error {
+		structs[s.Name] = len(s.Tags)
+		return nil
+	})
+
+	result, err := m.Validate()
+	r.NoError(err)
+
+	//The structs are validated separately, the duplicate name is only reported in v2
+	r.Len(result.Findings, 1)
+	r.Equal("v2/models.User", result.Findings[0].Struct)
+	r.Equal("Name", result.Findings[0].Field)
+	r.Equal(map[string]int{"v1/models.User": 2, "v2/models.User": 3}, structs)
+	r.Len(m.Tags(), 2)
+
+	tag := m.TagsFor("v1/models.User")[0]
+	r.Equal("models", tag.GetPackage())
+	r.True(strings.HasSuffix(filepath.ToSlash(tag.GetPackagePath()), "models/api/v1/models"), tag.GetPackagePath())
+
+	//Patterns match the name qualified with any trailing part of the path
+	m.IncludeStructs("models.User")
+	m.ExcludeStructs("v2/*")
+
+	result, err = m.Validate()
+	r.NoError(err)
+	r.Empty(result.Findings)
+	r.Equal([]string{"v1/models.User"}, sortedStructNames(m.Tags()))
+
+	//A model qualified with the path only parses the file of that package
+	m = NewValidator(modelsPath)
+	m.AddDefaultProcessors("db")
+	m.SetRecursive(true)
+
+	result, err = m.Validate("v2/models.user")
+	r.NoError(err)
+	r.Len(result.Findings, 1)
+	r.Equal("User", result.Findings[0].Struct)
+}
@@ -0,0 +1,56 @@
+//go:build (linux || darwin || freebsd) && cgo
+
+package validator
+
+import (
+	"fmt"
+	"plugin"
+)
+
+// LoadPluginProcessors opens a Go plugin and adds the processors it exports.
+// The plugin must export a variable of this shape, keyed by tag name:
+//
+//	var Processors = map[string]func(*validator.Tag) []error{...}
+//
+// It may export struct processors as well:
+//
+//	var StructProcessors = map[string]func(*validator.StructInfo) []error{...}
+//
+// The plugin has to be built with the same version of this package, plugins are supported on linux, darwin and freebsd with cgo.
+func (v *Validator) LoadPluginProcessors(path string) error {
+	p, err := plugin.Open(path)
+
+	if err != nil {
+		return fmt.Errorf("Could not open plugin %v: %w", path, err)
+	}
+
+	symbol, err := p.Lookup("Processors")
+
+	if err != nil {
+		return fmt.Errorf("Plugin %v has no Processors symbol: %w", path, err)
+	}
+
+	processors, ok := symbol.(*map[string]func(*Tag) []error)
+
+	if !ok {
+		return fmt.Errorf("Plugin %v exports Processors as %T, expected map[string]func(*Tag) []error", path, symbol)
+	}
+
+	structProcessors := &map[string]func(*StructInfo) []error{}
+
+	if symbol, err := p.Lookup("StructProcessors"); err == nil {
+		if structProcessors, ok = symbol.(*map[string]func(*StructInfo) []error); !ok {
+			return fmt.Errorf("Plugin %v exports StructProcessors as %T, expected map[string]func(*StructInfo) []error", path, symbol)
+		}
+	}
+
+	for tag, processor := range *processors {
+		v.AddProcessor(tag, processor)
+	}
+
+	for tag, processor := range *structProcessors {
+		v.AddStructProcessor(tag, processor)
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+//go:build (linux || darwin || freebsd) && cgo
+
+package validator
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// buildPlugin compiles the source into a plugin in a temporary directory, with the race detector if the tests have it.
+func buildPlugin(t *testing.T, source string) string {
+	dir := t.TempDir()
+	r := require.New(t)
+
+	r.NoError(os.WriteFile(filepath.Join(dir, "plugin.go"), []byte(source), 0644))
+
+	path := filepath.Join(dir, "plugin.so")
+	args := []string{"build", "-buildmode=plugin"}
+
+	//A plugin only loads into a binary built with the same flags
+	if raceEnabled {
+		args = append(args, "-race")
+	}
+
+	cmd := exec.Command("go", append(args, "-o", path, filepath.Join(dir, "plugin.go"))...)
+	cmd.Dir = dir
+
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Skipf("plugins can't be built: %v\n%s", err, out)
+	}
+
+	return path
+}
+
+func Test_testLoadPluginProcessors(t *testing.T) {
+	r := require.New(t)
+
+	m := NewValidator(modelsPath)
+
+	err := m.LoadPluginProcessors(filepath.Join(t.TempDir(), "missing.so"))
+	r.ErrorContains(err, "Could not open plugin")
+
+	path := buildPlugin(t, "package main\n\nvar Rules = map[string]int{}\n")
+	err = m.LoadPluginProcessors(path)
+	r.ErrorContains(err, "has no Processors symbol")
+
+	path = buildPlugin(t, "package main\n\nvar Processors = map[string]int{}\n")
+	err = m.LoadPluginProcessors(path)
+	r.ErrorContains(err, "exports Processors as *map[string]int, expected map[string]func(*Tag) []error")
+}
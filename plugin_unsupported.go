@@ -0,0 +1,13 @@
+//go:build !((linux || darwin || freebsd) && cgo)
+
+package validator
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// LoadPluginProcessors is not supported on this platform, Go plugins need linux, darwin or freebsd with cgo.
+func (v *Validator) LoadPluginProcessors(path string) error {
+	return fmt.Errorf("Could not open plugin %v: plugins are not supported on %v/%v", path, runtime.GOOS, runtime.GOARCH)
+}
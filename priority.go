@@ -0,0 +1,77 @@
+package validator
+
+import (
+	"errors"
+	"sort"
+)
+
+// The priorities of tag processors, processors of a higher priority run first. AddProcessor adds them with PriorityDefault.
+const (
+	PriorityDefault = 0
+	//PriorityPrerequisite is the priority of checks the others rely on, e.g. that the tag value isn't empty
+	PriorityPrerequisite = 100
+)
+
+// errStop is appended to the errors of a processor by Stop.
+var errStop = errors.New("stop processing the tag")
+
+// Stop marks the errors of a processor as the root cause of the tag's problems, the processors of a lower priority aren't run on the tag.
+// Processors of the same priority still run, see AddProcessorWithPriority.
+//
+//	m.AddProcessorWithPriority("db", validator.PriorityPrerequisite, func(tag *validator.Tag) []error {
+//		if len(tag.GetValue()) == 0 {
+//			return validator.Stop([]error{errors.New("empty")})
+//		}
+//
+//		return nil
+//	})
+func Stop(errs []error) []error {
+	return append(append([]error{}, errs...), errStop)
+}
+
+// AddProcessorWithPriority works like AddProcessor, the processors of a tag run in order of their priority, then in the order they were added.
+// The processors of the tag and the ones of all tags are ordered together.
+func (v *Validator) AddProcessorWithPriority(tag string, priority int, processor func(t *Tag) []error) {
+	v.addProcessor(tag, v.processorLabel(processor), priority, processor)
+}
+
+func (v *Validator) addProcessor(tag, name string, priority int, processor func(t *Tag) []error) {
+	v.processors[tag] = append(v.processors[tag], tagProcessor{name: name, run: processor, priority: priority})
+}
+
+// addOptionsProcessor adds a processor reading the options of the validator, it is given the snapshot of the run,
+// so changing the options during a run doesn't affect it.
+func (v *Validator) addOptionsProcessor(tag, name string, priority int, processor func(r *Validator, t *Tag) []error) {
+	v.processors[tag] = append(v.processors[tag], tagProcessor{name: name, runWith: processor, priority: priority})
+}
+
+// byPriority orders the processors by their priority, processors of the same priority keep their order.
+func byPriority(processors []tagProcessor) []tagProcessor {
+	sort.SliceStable(processors, func(i, j int) bool {
+		return processors[i].priority > processors[j].priority
+	})
+
+	return processors
+}
+
+// stopped removes the mark of Stop from the errors of a processor and reports whether it was there.
+func stopped(errs []error) ([]error, bool) {
+	for i, err := range errs {
+		if err != errStop {
+			continue
+		}
+
+		//The errors are copied, a processor may hold on to the ones it returned
+		kept := append([]error{}, errs[:i]...)
+
+		for _, err := range errs[i+1:] {
+			if err != errStop {
+				kept = append(kept, err)
+			}
+		}
+
+		return kept, true
+	}
+
+	return errs, false
+}
@@ -0,0 +1,109 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var emptyTagsModel = "package models\n\ntype Customer struct {\n" +
+	"ID string `db:\"id\"`\n" +
+	"FirstName string `db:\"\"`\n" +
+	"LastName string `db:\",omitempty\"`\n" +
+	"}\n"
+
+// addRedundantProcessors adds processors which report what an empty value implies.
+func addRedundantProcessors(m *Validator, priority int) {
+	for _, check := range []string{"too short", "not a column", "no table prefix"} {
+		check := check
+
+		m.AddProcessorWithPriority("db", priority, func(tag *Tag) []error {
+			name, _, _ := strings.Cut(tag.GetValue(), ",")
+
+			if len(name) > 2 {
+				return nil
+			}
+
+			return []error{fmt.Errorf("%v: %v", check, tag.GetFieldName())}
+		})
+	}
+}
+
+func Test_testStopEmptyValue(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", emptyTagsModel)
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("db")
+	addRedundantProcessors(&m, PriorityDefault)
+
+	result, err := m.Validate()
+
+	r.NoError(err)
+	r.Len(result.Findings, 3+1+1)
+	r.Equal("Tag cannot be empty Customer.db", result.Findings[3].Error())
+	r.Equal("Tag name cannot be empty Customer.db, only options are given", result.Findings[4].Error())
+	r.Equal(5+1+1, m.Coverage()["db"].ProcessorsRun)
+
+	//The short id is reported by every processor
+	for _, finding := range result.Findings[:3] {
+		r.Equal("ID", finding.Field)
+	}
+
+	//Processors running before the empty value check aren't skipped
+	m = NewValidator(modelsPath)
+	m.AddDefaultProcessors("db")
+	addRedundantProcessors(&m, PriorityPrerequisite+1)
+
+	result, err = m.Validate()
+
+	r.NoError(err)
+	r.Len(result.Findings, 3+4+4)
+}
+
+func Test_testProcessorPriority(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", "package models\n\ntype Customer struct {\n"+
+		"ID string `db:\"id\"`\n"+
+		"}\n")
+	defer os.RemoveAll("./models")
+
+	calls := []string{}
+	record := func(name string, errs []error) func(tag *Tag) []error {
+		return func(tag *Tag) []error {
+			calls = append(calls, name)
+			return errs
+		}
+	}
+
+	m := NewValidator(modelsPath)
+	m.AddProcessor("db", record("first", nil))
+	m.AddProcessorWithPriority(AllTags, 10, record("all", nil))
+	m.AddProcessorWithPriority("db", -5, record("last", nil))
+	m.AddProcessorWithPriority("db", 10, record("prerequisite", nil))
+	m.AddProcessor(AllTags, record("second", nil))
+
+	r.Empty(m.Run())
+	r.Equal([]string{"prerequisite", "all", "first", "second", "last"}, calls)
+
+	//Processors of the same priority still run, the lower ones don't
+	calls = nil
+	m = NewValidator(modelsPath)
+	m.AddProcessor("db", record("first", nil))
+	m.AddProcessorWithPriority("db", 10, record("stop", Stop([]error{errors.New("root cause")})))
+	m.AddProcessorWithPriority("db", 10, record("same", nil))
+	m.AddProcessorWithPriority(AllTags, 10, record("all", Stop(nil)))
+
+	errs := m.Run()
+
+	r.Len(errs, 1)
+	r.Equal("root cause", errs[0].Error())
+	r.Equal([]string{"stop", "same", "all"}, calls)
+}
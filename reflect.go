@@ -0,0 +1,119 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"go/token"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// SourceReflection is the Source of the findings about tags read from runtime types by ValidateTypes.
+const SourceReflection = "reflection"
+
+// ValidateTypes validates the tags of the types of the given values, read via reflection instead of parsing source files.
+// It runs the same processors and checks as Validate, e.g. for types declared in other modules or registered at runtime.
+// A reflect.Type can be passed as well.
+//
+// Pointers, slices, arrays, maps and channels are followed to their element type and the named struct types of fields,
+// embedded ones included, are validated too, each once. Structs are named after their type without the package, Tag.GetPackage returns it.
+// The findings have no position, their Source is SourceReflection.
+func (v *Validator) ValidateTypes(types ...interface{}) (*RunResult, error) {
+	r := v.snapshot()
+	defer v.publish(r)
+
+	return r.validate(context.Background(), func(_ context.Context, tags []string) (collection, error) {
+		return r.collectTypes(tags, types...)
+	})
+}
+
+// collectTypes collects the given tags from the struct types reachable from the types of the values.
+func (v *Validator) collectTypes(tags []string, values ...interface{}) (collection, error) {
+	v.fset = nil
+	v.packages = nil
+	v.qualifiers = nil
+
+	col := v.newCollector(tags)
+	col.source = SourceReflection
+	c := collection{tags: map[string][]*Tag{}}
+	visited := map[reflect.Type]bool{}
+	unnamed := 0
+	start := time.Now()
+
+	var walk func(t reflect.Type, structName *string, decl *declaration)
+	walk = func(t reflect.Type, structName *string, decl *declaration) {
+		for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map || t.Kind() == reflect.Chan {
+			t = t.Elem()
+		}
+
+		if t.Kind() != reflect.Struct || visited[t] {
+			return
+		}
+
+		//Unnamed struct types belong to the struct declaring them, like in the source
+		if len(t.Name()) > 0 {
+			visited[t] = true
+			name := t.Name()
+			structName = &name
+			//Types of one name from different packages are different structs
+			pkg, _, _ := strings.Cut(t.String(), ".")
+			decl = &declaration{key: t.PkgPath() + "." + name, pkg: pkg, pkgPath: t.PkgPath()}
+		} else if structName == nil {
+			unnamed++
+			name := fmt.Sprintf("struct#%v", unnamed)
+			structName = &name
+			decl = &declaration{key: name}
+		}
+
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+
+			if col.isExcluded(field.Name) {
+				c.fieldsExcluded++
+				continue
+			}
+
+			//Malformed tags keep the pairs found before the problem, like reflect.StructTag.Lookup
+			pairs, _ := scanTag(string(field.Tag))
+			fieldTags, findings := col.collectField(structName, decl, field.Name, pairs, token.Position{}, nil)
+
+			if col.checkSyntax {
+				if syntaxErr := validateTagSyntax(string(field.Tag)); syntaxErr != nil {
+					findings = append(findings, col.newSyntaxError(*structName, field.Name, token.Position{}, syntaxErr))
+				}
+			}
+
+			if len(fieldTags) > 0 {
+				c.tags[*structName] = append(c.tags[*structName], fieldTags...)
+			}
+
+			c.findings = append(c.findings, findings...)
+			walk(field.Type, structName, decl)
+		}
+	}
+
+	for i, value := range values {
+		t, ok := value.(reflect.Type)
+
+		if !ok {
+			t = reflect.TypeOf(value)
+		}
+
+		if t == nil {
+			return collection{}, fmt.Errorf("Type %v is nil", i)
+		}
+
+		walk(t, nil, nil)
+	}
+
+	v.logger.Debug("collected types", "types", len(values), "structs", len(c.tags), "duration", time.Since(start))
+
+	v.stats.StructsFound = len(c.tags)
+	v.stats.FieldsExcluded = c.fieldsExcluded
+
+	c.warnings = v.filterStructs(&c)
+	v.tags = c.tags
+
+	return c, nil
+}
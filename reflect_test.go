@@ -0,0 +1,75 @@
+package validator
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type reflectAddress struct {
+	City string `db:"city_"`
+}
+
+type reflectAudit struct {
+	CreatedAt time.Time `db:"created_at" db:"created"`
+}
+
+type reflectCustomer struct {
+	ID      int              `db:"id"`
+	Name    string           `db:"id"`
+	Address []reflectAddress `db:"address"`
+	Parent  *reflectCustomer `db:"parent_id"`
+	Meta    struct {
+		Note string `db:"note"`
+	} `db:"meta"`
+	reflectAudit
+}
+
+func Test_testValidateTypes(t *testing.T) {
+	r := require.New(t)
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("db")
+
+	result, err := m.ValidateTypes(&reflectCustomer{}, reflect.TypeOf(reflectAddress{}))
+
+	r.NoError(err)
+
+	messages := []string{}
+
+	for _, finding := range result.Findings {
+		r.Equal(SourceReflection, finding.Source)
+		r.False(finding.Pos.IsValid())
+		messages = append(messages, finding.Error())
+	}
+
+	sort.Strings(messages)
+
+	r.Equal([]string{
+		"Duplicate tag key db in reflectAudit.CreatedAt with values created_at and created",
+		"Duplicate tag value id in reflectCustomer.db (suggested: rename it, the value is held by reflectCustomer.ID)",
+		"Tag cannot end on _ in  reflectAddress.db.city_, charset [a-z0-9_, ] (suggested: city)",
+	}, messages)
+
+	//The cycle through Parent is followed once and the unnamed struct belongs to the struct declaring it
+	fields := []string{}
+
+	for _, tag := range m.TagsFor("reflectCustomer") {
+		fields = append(fields, tag.GetFieldName())
+	}
+
+	r.Equal([]string{"ID", "Name", "Address", "Parent", "Meta", "Note"}, fields)
+	r.Len(m.Tags(), 3)
+	r.Equal(3, result.Stats.StructsFound)
+
+	_, err = m.ValidateTypes(nil)
+
+	r.EqualError(err, "Type 0 is nil")
+
+	_, err = m.ValidateTypes(0)
+
+	r.ErrorIs(err, ErrNoTags)
+}
@@ -0,0 +1,433 @@
+package validator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/token"
+	"io"
+	"sort"
+)
+
+// Report organizes the outcome of a run.
+// Findings are sorted by file and position, errors that are no findings, such as files failing to parse, are kept apart.
+// Files holds the outcome of every Go file of the run, see FileDiagnostics.
+type Report struct {
+	Findings []*ValidationError
+	Errors   []error
+	Stats    *Stats
+	Files    []FileDiag
+}
+
+// FileReport holds the findings of one file grouped by struct.
+type FileReport struct {
+	Path    string
+	Structs []StructReport
+}
+
+// StructReport holds the findings of one struct.
+type StructReport struct {
+	Struct   string
+	Findings []*ValidationError
+}
+
+// NewReport creates a report from the errors returned by Run.
+func NewReport(errs []error) *Report {
+	r := &Report{
+		Findings: []*ValidationError{},
+		Errors:   []error{},
+	}
+
+	for _, err := range errs {
+		var finding *ValidationError
+
+		if errors.As(err, &finding) {
+			r.Findings = append(r.Findings, finding)
+			continue
+		}
+
+		r.Errors = append(r.Errors, err)
+	}
+
+	sortFindings(r.Findings)
+
+	return r
+}
+
+// RunReport runs the validator and creates a report of its outcome, including the stats of the run and the outcome of its files.
+func (v *Validator) RunReport(models ...string) *Report {
+	result, err := v.Validate(models...)
+	r := &Report{
+		Findings: append([]*ValidationError{}, result.Findings...),
+		Errors:   append([]error{}, result.Warnings...),
+		Stats:    &result.Stats,
+		Files:    v.FileDiagnostics(),
+	}
+
+	if err != nil {
+		r.Errors = append(r.Errors, err)
+	}
+
+	sortFindings(r.Findings)
+
+	return r
+}
+
+// sortFindings orders findings by path and position, then by struct, field and message.
+func sortFindings(findings []*ValidationError) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+
+		if a.Pos.Filename != b.Pos.Filename {
+			return a.Pos.Filename < b.Pos.Filename
+		}
+
+		if a.Pos.Line != b.Pos.Line {
+			return a.Pos.Line < b.Pos.Line
+		}
+
+		if a.Pos.Column != b.Pos.Column {
+			return a.Pos.Column < b.Pos.Column
+		}
+
+		if a.Struct != b.Struct {
+			return a.Struct < b.Struct
+		}
+
+		if a.Field != b.Field {
+			return a.Field < b.Field
+		}
+
+		return a.Error() < b.Error()
+	})
+}
+
+// ByFile groups the findings by file and, within a file, by struct in order of their first finding.
+func (r *Report) ByFile() []FileReport {
+	files := []FileReport{}
+
+	for _, finding := range r.Findings {
+		if len(files) == 0 || files[len(files)-1].Path != finding.Pos.Filename {
+			files = append(files, FileReport{Path: finding.Pos.Filename})
+		}
+
+		file := &files[len(files)-1]
+		found := false
+
+		for i := range file.Structs {
+			if file.Structs[i].Struct == finding.Struct {
+				file.Structs[i].Findings = append(file.Structs[i].Findings, finding)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			file.Structs = append(file.Structs, StructReport{finding.Struct, []*ValidationError{finding}})
+		}
+	}
+
+	return files
+}
+
+// ByStruct groups the findings by struct name, sorted by name.
+func (r *Report) ByStruct() []StructReport {
+	index := map[string]int{}
+	structs := []StructReport{}
+
+	for _, finding := range r.Findings {
+		i, exists := index[finding.Struct]
+
+		if !exists {
+			i = len(structs)
+			index[finding.Struct] = i
+			structs = append(structs, StructReport{Struct: finding.Struct})
+		}
+
+		structs[i].Findings = append(structs[i].Findings, finding)
+	}
+
+	sort.SliceStable(structs, func(i, j int) bool {
+		return structs[i].Struct < structs[j].Struct
+	})
+
+	return structs
+}
+
+// ProcessorCount is the number of findings of one processor.
+type ProcessorCount struct {
+	Processor string
+	Count     int
+}
+
+// ByProcessor counts the findings per processor, the processors with the most findings come first.
+func (r *Report) ByProcessor() []ProcessorCount {
+	counts := []ProcessorCount{}
+
+	for name, count := range countByProcessor(r.Findings) {
+		counts = append(counts, ProcessorCount{name, count})
+	}
+
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+
+		return counts[i].Processor < counts[j].Processor
+	})
+
+	return counts
+}
+
+// WriteText writes the report in a human readable form.
+// It prints a header per file, the findings of every struct with their positions, a summary line and the findings per processor.
+func (r *Report) WriteText(w io.Writer) error {
+	ew := &errWriter{w: w}
+	files := r.ByFile()
+	structs := 0
+
+	for _, file := range files {
+		path := file.Path
+
+		if len(path) == 0 {
+			path = "(no file)"
+		}
+
+		ew.printf("%v\n", path)
+
+		for _, s := range file.Structs {
+			structs++
+			ew.printf("  %v\n", s.Struct)
+
+			for _, finding := range s.Findings {
+				if finding.severity() == SeverityWarning {
+					ew.printf("    %v:%v: warning: %v\n", finding.Pos.Line, finding.Pos.Column, finding.Error())
+					continue
+				}
+
+				ew.printf("    %v:%v: %v\n", finding.Pos.Line, finding.Pos.Column, finding.Error())
+			}
+		}
+
+		ew.printf("\n")
+	}
+
+	for _, err := range r.Errors {
+		ew.printf("error: %v\n", err)
+	}
+
+	ew.printf("%v findings in %v structs across %v files", len(r.Findings), structs, len(files))
+
+	if len(r.Errors) > 0 {
+		ew.printf(", %v errors", len(r.Errors))
+	}
+
+	ew.printf("\n")
+
+	for _, count := range r.ByProcessor() {
+		ew.printf("  %v from %v\n", count.Count, count.Processor)
+	}
+
+	return ew.err
+}
+
+// reportFinding is the JSON form of a finding.
+type reportFinding struct {
+	File       string `json:"file,omitempty"`
+	Line       int    `json:"line,omitempty"`
+	Column     int    `json:"column,omitempty"`
+	ValueLine  int    `json:"value_line,omitempty"`
+	ValueCol   int    `json:"value_column,omitempty"`
+	Struct     string `json:"struct"`
+	Field      string `json:"field,omitempty"`
+	Tag        string `json:"tag"`
+	Value      string `json:"value"`
+	Message    string `json:"message"`
+	Suggestion string `json:"suggestion,omitempty"`
+	Processor  string `json:"processor,omitempty"`
+	Kind       string `json:"kind,omitempty"`
+	Severity   string `json:"severity"`
+	// Count is the number of occurrences of a de-duplicated finding, it is left out for a single one.
+	Count int          `json:"count,omitempty"`
+	Edits []reportEdit `json:"edits,omitempty"`
+}
+
+// reportEdit is the JSON form of a text edit, its offsets are byte offsets within the file of the finding.
+type reportEdit struct {
+	Offset    int    `json:"offset"`
+	EndOffset int    `json:"end_offset"`
+	Line      int    `json:"line"`
+	Column    int    `json:"column"`
+	EndLine   int    `json:"end_line"`
+	EndColumn int    `json:"end_column"`
+	NewText   string `json:"new_text"`
+}
+
+func newReportFinding(finding *ValidationError) reportFinding {
+	f := reportFinding{
+		File:       finding.Pos.Filename,
+		Line:       finding.Pos.Line,
+		Column:     finding.Pos.Column,
+		ValueLine:  finding.ValuePos.Line,
+		ValueCol:   finding.ValuePos.Column,
+		Struct:     finding.Struct,
+		Field:      finding.Field,
+		Tag:        finding.Tag,
+		Value:      finding.Value,
+		Message:    finding.Message,
+		Suggestion: finding.Suggestion,
+		Processor:  finding.Processor,
+		Kind:       string(finding.Kind),
+		Severity:   string(finding.severity()),
+	}
+
+	for _, edit := range finding.Edits {
+		f.Edits = append(f.Edits, reportEdit{
+			Offset:    edit.Pos.Offset,
+			EndOffset: edit.End.Offset,
+			Line:      edit.Pos.Line,
+			Column:    edit.Pos.Column,
+			EndLine:   edit.End.Line,
+			EndColumn: edit.End.Column,
+			NewText:   edit.NewText,
+		})
+	}
+
+	return f
+}
+
+// finding turns the JSON form back into a finding, the errors it wrapped are lost.
+func (f reportFinding) finding() *ValidationError {
+	finding := &ValidationError{
+		Struct:     f.Struct,
+		Field:      f.Field,
+		Tag:        f.Tag,
+		Value:      f.Value,
+		Message:    f.Message,
+		Pos:        token.Position{Filename: f.File, Line: f.Line, Column: f.Column},
+		Suggestion: f.Suggestion,
+		Processor:  f.Processor,
+		Kind:       MessageKind(f.Kind),
+		Severity:   Severity(f.Severity),
+	}
+
+	if f.ValueLine > 0 {
+		finding.ValuePos = token.Position{Filename: f.File, Line: f.ValueLine, Column: f.ValueCol}
+	}
+
+	for _, edit := range f.Edits {
+		finding.Fixable = true
+		finding.Edits = append(finding.Edits, TextEdit{
+			Pos:     token.Position{Filename: f.File, Offset: edit.Offset, Line: edit.Line, Column: edit.Column},
+			End:     token.Position{Filename: f.File, Offset: edit.EndOffset, Line: edit.EndLine, Column: edit.EndColumn},
+			NewText: edit.NewText,
+		})
+	}
+
+	return finding
+}
+
+type reportJSON struct {
+	Findings    []reportFinding `json:"findings"`
+	Errors      []string        `json:"errors"`
+	ByProcessor map[string]int  `json:"by_processor"`
+	Summary     *Stats          `json:"summary,omitempty"`
+	Files       []reportFile    `json:"files,omitempty"`
+}
+
+// reportFile is the JSON form of a file diagnostic.
+type reportFile struct {
+	Path   string `json:"path"`
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+	Error  string `json:"error,omitempty"`
+	Cached bool   `json:"cached,omitempty"`
+}
+
+// WriteJSON writes the report as a JSON document with the stats of the run as a summary block, followed by the outcome of its files.
+func (r *Report) WriteJSON(w io.Writer) error {
+	doc := reportJSON{
+		Findings: make([]reportFinding, 0, len(r.Findings)),
+		Errors:   make([]string, 0, len(r.Errors)),
+		Summary:  r.Stats,
+
+		ByProcessor: countByProcessor(r.Findings),
+	}
+
+	for _, finding := range r.Findings {
+		doc.Findings = append(doc.Findings, newReportFinding(finding))
+	}
+
+	for _, err := range r.Errors {
+		doc.Errors = append(doc.Errors, err.Error())
+	}
+
+	for _, file := range r.Files {
+		f := reportFile{Path: file.Path, Status: string(file.Status), Reason: file.Reason, Cached: file.Cached}
+
+		if file.Err != nil {
+			f.Error = file.Err.Error()
+		}
+
+		doc.Files = append(doc.Files, f)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(doc)
+}
+
+// ReadJSONReport reads a report written by WriteJSON, e.g. to compare it with another one, see Diff.
+// A finding with a count stands for that many occurrences of it.
+func ReadJSONReport(r io.Reader) (*Report, error) {
+	doc := reportJSON{}
+
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid report: %v", err)
+	}
+
+	report := &Report{
+		Findings: make([]*ValidationError, 0, len(doc.Findings)),
+		Errors:   make([]error, 0, len(doc.Errors)),
+		Stats:    doc.Summary,
+	}
+
+	for _, f := range doc.Findings {
+		for i := 0; i < max(f.Count, 1); i++ {
+			report.Findings = append(report.Findings, f.finding())
+		}
+	}
+
+	for _, err := range doc.Errors {
+		report.Errors = append(report.Errors, errors.New(err))
+	}
+
+	for _, f := range doc.Files {
+		file := FileDiag{Path: f.Path, Status: FileStatus(f.Status), Reason: f.Reason, Cached: f.Cached}
+
+		if len(f.Error) > 0 {
+			file.Err = errors.New(f.Error)
+		}
+
+		report.Files = append(report.Files, file)
+	}
+
+	sortFindings(report.Findings)
+
+	return report, nil
+}
+
+// errWriter keeps the first write error, so a sequence of writes can be checked once.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (ew *errWriter) printf(format string, args ...interface{}) {
+	if ew.err != nil {
+		return
+	}
+
+	_, ew.err = fmt.Fprintf(ew.w, format, args...)
+}
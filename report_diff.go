@@ -0,0 +1,189 @@
+package validator
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// DiffEntry is a finding of a diff along with the number of its occurrences, e.g. a duplicate value reported for two structs of the same name.
+type DiffEntry struct {
+	Finding *ValidationError
+	Count   int
+}
+
+// ReportDiff classifies the findings of two reports, see Diff.
+type ReportDiff struct {
+	Added     []DiffEntry
+	Removed   []DiffEntry
+	Unchanged []DiffEntry
+}
+
+// diffKey identifies a finding regardless of its position, so code which only moved doesn't count as a change.
+type diffKey struct {
+	structName string
+	field      string
+	tag        string
+	message    string
+}
+
+func newDiffKey(finding *ValidationError) diffKey {
+	return diffKey{finding.Struct, finding.Field, finding.Tag, finding.Message}
+}
+
+// Diff compares the findings of an old and a new report, e.g. of the main branch and of a change to the conventions.
+// Findings are matched by struct, field, tag and message, their positions are ignored.
+// Findings occurring more often in the new report are added, the ones occurring less often are removed, by the difference of their counts.
+// The entries of the new report are used wherever there are some, so their positions are current.
+func Diff(oldReport, newReport *Report) *ReportDiff {
+	oldCounts, oldFindings, _ := countFindings(oldReport.Findings)
+	newCounts, newFindings, newOrder := countFindings(newReport.Findings)
+	d := &ReportDiff{
+		Added:     []DiffEntry{},
+		Removed:   []DiffEntry{},
+		Unchanged: []DiffEntry{},
+	}
+
+	for _, key := range newOrder {
+		added := newCounts[key] - oldCounts[key]
+		unchanged := min(newCounts[key], oldCounts[key])
+
+		if added > 0 {
+			d.Added = append(d.Added, DiffEntry{newFindings[key], added})
+		}
+
+		if unchanged > 0 {
+			d.Unchanged = append(d.Unchanged, DiffEntry{newFindings[key], unchanged})
+		}
+	}
+
+	//Removed ones are in the order of the old report
+	for _, finding := range oldReport.Findings {
+		key := newDiffKey(finding)
+
+		if oldFindings[key] != finding {
+			continue
+		}
+
+		if removed := oldCounts[key] - newCounts[key]; removed > 0 {
+			d.Removed = append(d.Removed, DiffEntry{finding, removed})
+		}
+	}
+
+	return d
+}
+
+// countFindings counts the findings by key and keeps the first finding of each key, in the order the keys first occur.
+func countFindings(findings []*ValidationError) (map[diffKey]int, map[diffKey]*ValidationError, []diffKey) {
+	counts := map[diffKey]int{}
+	first := map[diffKey]*ValidationError{}
+	order := []diffKey{}
+
+	for _, finding := range findings {
+		key := newDiffKey(finding)
+
+		if counts[key] == 0 {
+			first[key] = finding
+			order = append(order, key)
+		}
+
+		counts[key]++
+	}
+
+	return counts, first, order
+}
+
+// HasAdditions reports whether the new report has findings the old one doesn't, e.g. to fail a build on regressions only.
+func (d *ReportDiff) HasAdditions() bool {
+	return len(d.Added) > 0
+}
+
+// Additions returns a diff holding only the added findings, the other classes are nil.
+func (d *ReportDiff) Additions() *ReportDiff {
+	return &ReportDiff{Added: d.Added}
+}
+
+// WriteText writes the added findings prefixed with +, the removed ones with - and the unchanged ones indented, followed by a summary line.
+// The summary leaves out the removed and unchanged counts of a diff of the additions only.
+func (d *ReportDiff) WriteText(w io.Writer) error {
+	ew := &errWriter{w: w}
+
+	write := func(prefix string, entries []DiffEntry) {
+		for _, entry := range entries {
+			finding := entry.Finding
+			ew.printf("%v ", prefix)
+
+			if len(finding.Pos.Filename) > 0 {
+				ew.printf("%v:%v:%v: ", finding.Pos.Filename, finding.Pos.Line, finding.Pos.Column)
+			}
+
+			ew.printf("%v", finding.Error())
+
+			if entry.Count > 1 {
+				ew.printf(" (%v times)", entry.Count)
+			}
+
+			ew.printf("\n")
+		}
+	}
+
+	write("+", d.Added)
+	write("-", d.Removed)
+	write(" ", d.Unchanged)
+
+	ew.printf("%v added", countEntries(d.Added))
+
+	//A diff of the additions only doesn't know the other counts
+	if d.Removed != nil || d.Unchanged != nil {
+		ew.printf(", %v removed, %v unchanged", countEntries(d.Removed), countEntries(d.Unchanged))
+	}
+
+	ew.printf("\n")
+
+	return ew.err
+}
+
+// countEntries counts the occurrences of the entries.
+func countEntries(entries []DiffEntry) int {
+	count := 0
+
+	for _, entry := range entries {
+		count += entry.Count
+	}
+
+	return count
+}
+
+type diffJSON struct {
+	Added     []reportFinding `json:"added"`
+	Removed   []reportFinding `json:"removed"`
+	Unchanged []reportFinding `json:"unchanged"`
+}
+
+// WriteJSON writes the diff as a JSON document with a list of findings per class, in the form WriteJSON of Report uses.
+// Findings occurring more than once have a count.
+func (d *ReportDiff) WriteJSON(w io.Writer) error {
+	findings := func(entries []DiffEntry) []reportFinding {
+		list := make([]reportFinding, 0, len(entries))
+
+		for _, entry := range entries {
+			f := newReportFinding(entry.Finding)
+
+			if entry.Count > 1 {
+				f.Count = entry.Count
+			}
+
+			list = append(list, f)
+		}
+
+		return list
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(diffJSON{
+		Added:     findings(d.Added),
+		Removed:   findings(d.Removed),
+		Unchanged: findings(d.Unchanged),
+	})
+}
@@ -0,0 +1,147 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_testDiff(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", `package models
+
+type Customer struct {
+	ID        int    `+"`"+`db:"id"`+"`"+`
+	CreatedAt string `+"`"+`db:"created-at"`+"`"+`
+	UpdatedAt string `+"`"+`db:"updated-at"`+"`"+`
+}
+`)
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("db")
+
+	out := &bytes.Buffer{}
+	r.NoError(m.RunReport().WriteJSON(out))
+
+	old, err := ReadJSONReport(out)
+
+	r.NoError(err)
+	r.Len(old.Findings, 2)
+	r.Equal("default", old.Findings[0].Processor)
+	r.Equal(SeverityError, old.Findings[0].Severity)
+	r.Equal(5, old.Findings[0].Pos.Line)
+
+	//The struct moved down, created-at was fixed and a new rule flags updated-at
+	createFile("customer.go", `package models
+
+type Order struct {
+	ID int `+"`"+`db:"id"`+"`"+`
+}
+
+type Customer struct {
+	ID        int    `+"`"+`db:"id"`+"`"+`
+	CreatedAt string `+"`"+`db:"created"`+"`"+`
+	UpdatedAt string `+"`"+`db:"updated-at"`+"`"+`
+}
+`)
+	m.AddProcessorNamed("db", "short-names", NewMaxLengthProcessor(8))
+
+	d := Diff(old, m.RunReport())
+
+	r.Len(d.Added, 1)
+	r.Equal("short-names", d.Added[0].Finding.Processor)
+	r.Equal(10, d.Added[0].Finding.Pos.Line)
+	r.Len(d.Removed, 1)
+	r.Equal("created-at", d.Removed[0].Finding.Value)
+	r.Len(d.Unchanged, 1)
+	r.Equal("updated-at", d.Unchanged[0].Finding.Value)
+	r.Equal(10, d.Unchanged[0].Finding.Pos.Line)
+	r.True(d.HasAdditions())
+
+	text := &bytes.Buffer{}
+	r.NoError(d.Additions().WriteText(text))
+
+	lines := strings.Split(strings.TrimSuffix(text.String(), "\n"), "\n")
+
+	r.Len(lines, 2)
+	r.True(strings.HasPrefix(lines[0], "+ "))
+	r.True(strings.HasSuffix(lines[0], "customer.go:10:19: Tag value updated-at in Customer.db is 10 characters long, the maximum is 8"))
+	r.Equal("1 added", lines[1])
+
+	text.Reset()
+	r.NoError(d.WriteText(text))
+	r.True(strings.HasSuffix(text.String(), "1 added, 1 removed, 1 unchanged\n"))
+
+	r.False(Diff(old, old).HasAdditions())
+}
+
+func Test_testDiffCounts(t *testing.T) {
+	r := require.New(t)
+
+	report := func(counts ...int) *Report {
+		findings := []map[string]interface{}{}
+
+		for i, count := range counts {
+			findings = append(findings, map[string]interface{}{
+				"struct":  "Customer",
+				"tag":     "db",
+				"value":   "id",
+				"line":    i + 1,
+				"message": "Duplicate tag value id in Customer.db",
+				"count":   count,
+			})
+		}
+
+		data, err := json.Marshal(map[string]interface{}{"findings": findings, "errors": []string{}})
+		r.NoError(err)
+
+		report, err := ReadJSONReport(bytes.NewReader(data))
+		r.NoError(err)
+
+		return report
+	}
+
+	tests := []struct {
+		name                      string
+		old, new                  *Report
+		added, removed, unchanged int
+	}{
+		{"same", report(2), report(2), 0, 0, 2},
+		{"more", report(2), report(3), 1, 0, 2},
+		{"fewer", report(3), report(1), 0, 2, 1},
+		{"spread over entries", report(1, 1), report(2), 0, 0, 2},
+		{"no count", report(0), report(2), 1, 0, 1},
+		{"gone", report(2), report(), 0, 2, 0},
+	}
+
+	for _, test := range tests {
+		d := Diff(test.old, test.new)
+
+		r.Equal(test.added, countEntries(d.Added), test.name)
+		r.Equal(test.removed, countEntries(d.Removed), test.name)
+		r.Equal(test.unchanged, countEntries(d.Unchanged), test.name)
+	}
+
+	d := Diff(report(1), report(3))
+	out := &bytes.Buffer{}
+	r.NoError(d.WriteJSON(out))
+
+	doc := struct {
+		Added     []map[string]interface{}
+		Removed   []map[string]interface{}
+		Unchanged []map[string]interface{}
+	}{}
+
+	r.NoError(json.Unmarshal(out.Bytes(), &doc))
+	r.Len(doc.Added, 1)
+	r.Equal(float64(2), doc.Added[0]["count"])
+	r.Empty(doc.Removed)
+	r.Len(doc.Unchanged, 1)
+	r.Nil(doc.Unchanged[0]["count"])
+}
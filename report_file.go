@@ -0,0 +1,199 @@
+package validator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Format is the output format of a report.
+type Format string
+
+const (
+	FormatText  Format = "text"
+	FormatJSON  Format = "json"
+	FormatHTML  Format = "html"
+	FormatSARIF Format = "sarif"
+)
+
+// ErrStaleReport is returned by CheckReportFile when the report file doesn't match the outcome of the run.
+var ErrStaleReport = errors.New("report file is out of date")
+
+// Write writes the report in the given format.
+func (r *Report) Write(w io.Writer, format Format) error {
+	switch format {
+	case FormatText:
+		return r.WriteText(w)
+	case FormatJSON:
+		return r.WriteJSON(w)
+	case FormatHTML:
+		return r.WriteHTML(w)
+	case FormatSARIF:
+		return r.WriteSARIF(w)
+	}
+
+	return fmt.Errorf("unknown format %v", format)
+}
+
+// WriteReportFile runs the validator and writes the report to the file at path, e.g. from a go:generate directive.
+// The file is replaced atomically. File names in the report are relative to its directory and the duration of the run is left out,
+// so the file only changes along with the findings.
+func (v *Validator) WriteReportFile(path string, format Format) error {
+	content, err := v.reportFile(path, format)
+
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(path, content)
+}
+
+// CheckReportFile runs the validator and compares the report with the file at path, without changing it.
+// It returns ErrStaleReport if the file is missing or WriteReportFile would change it.
+func (v *Validator) CheckReportFile(path string, format Format) error {
+	content, err := v.reportFile(path, format)
+
+	if err != nil {
+		return err
+	}
+
+	existing, err := os.ReadFile(path)
+
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w: %v doesn't exist", ErrStaleReport, path)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(existing, content) {
+		return fmt.Errorf("%w: %v", ErrStaleReport, path)
+	}
+
+	return nil
+}
+
+// reportFile runs the validator and renders the report to be stored at path.
+func (v *Validator) reportFile(path string, format Format) ([]byte, error) {
+	dir, err := filepath.Abs(filepath.Dir(path))
+
+	if err != nil {
+		return nil, err
+	}
+
+	report := v.RunReport()
+	stable := &Report{
+		Findings: make([]*ValidationError, 0, len(report.Findings)),
+		Errors:   make([]error, 0, len(report.Errors)),
+	}
+
+	//The paths of an fs.FS are relative to its root already
+	relative := func(fileName string) string {
+		if v.fsys != nil {
+			return fileName
+		}
+
+		return relativePath(dir, fileName)
+	}
+
+	for _, finding := range report.Findings {
+		finding := *finding
+		finding.Pos.Filename = relative(finding.Pos.Filename)
+		finding.ValuePos.Filename = relative(finding.ValuePos.Filename)
+		finding.ValueEnd.Filename = relative(finding.ValueEnd.Filename)
+		stable.Findings = append(stable.Findings, &finding)
+	}
+
+	//Errors only have a message, the directory is cut from the file names in it
+	for _, err := range report.Errors {
+		stable.Errors = append(stable.Errors, errors.New(strings.ReplaceAll(err.Error(), dir+string(filepath.Separator), "")))
+	}
+
+	//Whether a file came from the cache depends on the runs before
+	for _, file := range report.Files {
+		file.Path = relative(file.Path)
+		file.Cached = false
+
+		if file.Err != nil {
+			file.Err = errors.New(strings.ReplaceAll(file.Err.Error(), dir+string(filepath.Separator), ""))
+		}
+
+		stable.Files = append(stable.Files, file)
+	}
+
+	if report.Stats != nil {
+		stats := *report.Stats
+		stats.Duration = 0
+		stats.ValidateDuration = 0
+		stats.FilesCached = 0
+		stable.Stats = &stats
+	}
+
+	sortFindings(stable.Findings)
+	buf := &bytes.Buffer{}
+
+	if err := stable.Write(buf, format); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// relativePath returns the file name relative to dir with forward slashes, or unchanged if it can't be made relative.
+func relativePath(dir, fileName string) string {
+	if len(fileName) == 0 {
+		return fileName
+	}
+
+	abs, err := filepath.Abs(fileName)
+
+	if err != nil {
+		return fileName
+	}
+
+	rel, err := filepath.Rel(dir, abs)
+
+	if err != nil {
+		return fileName
+	}
+
+	return filepath.ToSlash(rel)
+}
+
+// writeFileAtomic writes the content to a temporary file next to path and renames it over path,
+// so readers never see a partially written file.
+func writeFileAtomic(path string, content []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	perm := os.FileMode(0644)
+
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
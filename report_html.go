@@ -0,0 +1,106 @@
+package validator
+
+import (
+	"html/template"
+	"io"
+	"sort"
+)
+
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Struct tag validation report</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+th { background: #f0f0f0; }
+td.count { text-align: right; }
+code { font-family: monospace; }
+input { margin-bottom: 1em; padding: 4px; width: 30em; }
+.errors li { color: #a00; }
+</style>
+</head>
+<body>
+<h1>Struct tag validation report</h1>
+<p>{{len .Findings}} findings in {{.Structs}} structs across {{.Files}} files{{with .Errors}}, {{len .}} errors{{end}}</p>
+<h2>Summary</h2>
+<table>
+<tr><th>Tag</th><th>Findings</th></tr>
+{{- range .Counts}}
+<tr><td><code>{{.Name}}</code></td><td class="count">{{.Count}}</td></tr>
+{{- end}}
+</table>
+{{- with .Errors}}
+<h2>Errors</h2>
+<ul class="errors">
+{{- range .}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{- end}}
+<h2>Findings</h2>
+<input id="filter" type="search" placeholder="Filter findings" oninput="filterFindings(this.value)">
+<table id="findings">
+<tr><th>Struct</th><th>Field</th><th>Tag</th><th>Value</th><th>Message</th><th>Location</th></tr>
+{{- range .Findings}}
+<tr class="finding">
+<td>{{.Struct}}</td><td>{{.Field}}</td><td><code>{{.Tag}}</code></td><td><code>{{.Value}}</code></td><td>{{.Error}}</td>
+<td>{{if .Pos.Filename}}{{.Pos.Filename}}:{{.Pos.Line}}{{end}}</td>
+</tr>
+{{- end}}
+</table>
+<script>
+function filterFindings(text) {
+	text = text.toLowerCase();
+	document.querySelectorAll("#findings tr.finding").forEach(function (row) {
+		row.style.display = row.textContent.toLowerCase().indexOf(text) === -1 ? "none" : "";
+	});
+}
+</script>
+</body>
+</html>
+`))
+
+type reportCount struct {
+	Name  string
+	Count int
+}
+
+// WriteHTML writes the report as a standalone HTML page.
+// It holds a summary of the findings per tag and a filterable table of all findings, styles are inlined.
+func (r *Report) WriteHTML(w io.Writer) error {
+	counts := map[string]int{}
+
+	for _, finding := range r.Findings {
+		counts[finding.Tag]++
+	}
+
+	data := struct {
+		*Report
+		Structs int
+		Files   int
+		Counts  []reportCount
+	}{
+		Report: r,
+		Counts: make([]reportCount, 0, len(counts)),
+	}
+
+	for name, count := range counts {
+		data.Counts = append(data.Counts, reportCount{name, count})
+	}
+
+	sort.Slice(data.Counts, func(i, j int) bool {
+		return data.Counts[i].Name < data.Counts[j].Name
+	})
+
+	files := r.ByFile()
+	data.Files = len(files)
+
+	for _, file := range files {
+		data.Structs += len(file.Structs)
+	}
+
+	return reportTemplate.Execute(w, data)
+}
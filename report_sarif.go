@@ -0,0 +1,188 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+)
+
+const sarifVersion = "2.1.0"
+
+const sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool        sarifTool         `json:"tool"`
+	Invocations []sarifInvocation `json:"invocations"`
+	Results     []sarifResult     `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string `json:"name"`
+	InformationURI string `json:"informationUri"`
+}
+
+type sarifInvocation struct {
+	ExecutionSuccessful bool                `json:"executionSuccessful"`
+	Notifications       []sarifNotification `json:"toolExecutionNotifications,omitempty"`
+}
+
+type sarifNotification struct {
+	Level   string       `json:"level"`
+	Message sarifMessage `json:"message"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+	Fixes     []sarifFix      `json:"fixes,omitempty"`
+}
+
+type sarifFix struct {
+	Description     sarifMessage          `json:"description"`
+	ArtifactChanges []sarifArtifactChange `json:"artifactChanges"`
+}
+
+type sarifArtifactChange struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Replacements     []sarifReplacement    `json:"replacements"`
+}
+
+type sarifReplacement struct {
+	DeletedRegion   sarifRegion          `json:"deletedRegion"`
+	InsertedContent sarifArtifactContent `json:"insertedContent"`
+}
+
+type sarifArtifactContent struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+	//The byte offsets are only given for the regions of fixes, a length of zero is an insertion
+	ByteOffset *int `json:"byteOffset,omitempty"`
+	ByteLength *int `json:"byteLength,omitempty"`
+}
+
+// WriteSARIF writes the report as a SARIF 2.1.0 log, e.g. for code scanning.
+// The region of a finding covers the tag value, or starts at the tag literal for findings about a whole field.
+// Columns are counted in bytes like go/token does, they match the SARIF code point columns as long as tags are ASCII.
+// The edits of fixable findings are written as fixes, their regions are located by byte offsets as well.
+func (r *Report) WriteSARIF(w io.Writer) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "tagvalidator",
+			InformationURI: "https://github.com/petar-dambovaliev/struct-tag-validator",
+		}},
+		Invocations: []sarifInvocation{{ExecutionSuccessful: len(r.Errors) == 0}},
+		Results:     make([]sarifResult, 0, len(r.Findings)),
+	}
+
+	for _, err := range r.Errors {
+		run.Invocations[0].Notifications = append(run.Invocations[0].Notifications, sarifNotification{
+			Level:   "error",
+			Message: sarifMessage{err.Error()},
+		})
+	}
+
+	for _, finding := range r.Findings {
+		result := sarifResult{
+			RuleID:  finding.Tag,
+			Level:   string(finding.severity()),
+			Message: sarifMessage{finding.Error()},
+		}
+
+		if len(finding.Pos.Filename) > 0 {
+			region := sarifRegion{StartLine: finding.Pos.Line, StartColumn: finding.Pos.Column}
+
+			if finding.ValuePos.IsValid() {
+				region = sarifRegion{
+					StartLine:   finding.ValuePos.Line,
+					StartColumn: finding.ValuePos.Column,
+					EndLine:     finding.ValueEnd.Line,
+					EndColumn:   finding.ValueEnd.Column,
+				}
+			}
+
+			result.Locations = []sarifLocation{{sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{filepath.ToSlash(finding.Pos.Filename)},
+				Region:           region,
+			}}}
+		}
+
+		if len(finding.Edits) > 0 {
+			result.Fixes = []sarifFix{newSarifFix(finding)}
+		}
+
+		run.Results = append(run.Results, result)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	})
+}
+
+// newSarifFix describes the edits of a finding as a fix of the file it was reported for.
+func newSarifFix(finding *ValidationError) sarifFix {
+	change := sarifArtifactChange{
+		ArtifactLocation: sarifArtifactLocation{filepath.ToSlash(finding.Pos.Filename)},
+		Replacements:     make([]sarifReplacement, 0, len(finding.Edits)),
+	}
+
+	for _, edit := range finding.Edits {
+		offset, length := edit.Pos.Offset, edit.End.Offset-edit.Pos.Offset
+		change.Replacements = append(change.Replacements, sarifReplacement{
+			DeletedRegion: sarifRegion{
+				StartLine:   edit.Pos.Line,
+				StartColumn: edit.Pos.Column,
+				EndLine:     edit.End.Line,
+				EndColumn:   edit.End.Column,
+				ByteOffset:  &offset,
+				ByteLength:  &length,
+			},
+			InsertedContent: sarifArtifactContent{edit.NewText},
+		})
+	}
+
+	return sarifFix{
+		Description:     sarifMessage{fmt.Sprintf("Replace %v with %v", finding.Value, finding.Suggestion)},
+		ArtifactChanges: []sarifArtifactChange{change},
+	}
+}
@@ -0,0 +1,279 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func Test_testReport(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", `package models
+
+type Customer struct {
+	ID        int    `+"`"+`db:"id"`+"`"+`
+	CreatedAt string `+"`"+`db:"created-at"`+"`"+`
+}
+
+type Order struct {
+	ID   int    `+"`"+`db:"id"`+"`"+`
+	Note string `+"`"+`db:"id"`+"`"+`
+}
+`)
+	createFile("account.go", `package models
+
+type Account struct {
+	Name string `+"`"+`db:"name_"`+"`"+`
+}
+`)
+	createFile("broken.go", "package models\n\ntype Broken struct {\n")
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("db")
+
+	report := m.RunReport()
+
+	r.Len(report.Findings, 3)
+	r.Len(report.Errors, 1)
+	r.Equal(2, report.Stats.FilesParsed)
+
+	dir := filepath.Join(os.Getenv("GOPATH"), "src", modelsPath)
+	files := report.ByFile()
+
+	r.Len(files, 2)
+	r.Equal(filepath.Join(dir, "account.go"), files[0].Path)
+	r.Equal(filepath.Join(dir, "customer.go"), files[1].Path)
+	r.Equal("Customer", files[1].Structs[0].Struct)
+	r.Equal("Order", files[1].Structs[1].Struct)
+
+	structs := report.ByStruct()
+
+	r.Len(structs, 3)
+	r.Equal("Account", structs[0].Struct)
+	r.Equal("Customer", structs[1].Struct)
+	r.Equal("Order", structs[2].Struct)
+
+	text := &bytes.Buffer{}
+	r.NoError(report.WriteText(text))
+
+	r.Equal(filepath.Join(dir, "account.go")+"\n"+
+		"  Account\n"+
+		"    4:14: Tag cannot end on _ in  Account.db.name_, charset [a-z0-9_, ] (suggested: name)\n"+
+		"\n"+
+		filepath.Join(dir, "customer.go")+"\n"+
+		"  Customer\n"+
+		"    5:19: Invalid symboles - in Customer.db.created-at, charset [a-z0-9_, ] (suggested: created_at)\n"+
+		"  Order\n"+
+		"    10:14: Duplicate tag value id in Order.db (suggested: rename it, the value is held by Order.ID)\n"+
+		"\n"+
+		"error: "+filepath.Join(dir, "broken.go")+":3:22: expected '}', found 'EOF'\n"+
+		"3 findings in 3 structs across 2 files, 1 errors\n"+
+		"  2 from default\n"+
+		"  1 from duplicates\n", text.String())
+
+	doc := struct {
+		Findings    []map[string]interface{}
+		Errors      []string
+		Summary     map[string]interface{}
+		ByProcessor map[string]interface{} `json:"by_processor"`
+	}{}
+
+	out := &bytes.Buffer{}
+	r.NoError(report.WriteJSON(out))
+	r.NoError(json.Unmarshal(out.Bytes(), &doc))
+
+	r.Len(doc.Findings, 3)
+	r.Equal("Account", doc.Findings[0]["struct"])
+	r.Equal("Name", doc.Findings[0]["field"])
+	r.Equal("name", doc.Findings[0]["suggestion"])
+	r.Equal(float64(4), doc.Findings[0]["line"])
+	r.Equal(float64(19), doc.Findings[0]["value_column"])
+	r.Equal("default", doc.Findings[0]["processor"])
+	r.Equal(map[string]interface{}{"default": float64(2), "duplicates": float64(1)}, doc.ByProcessor)
+	r.Len(doc.Errors, 1)
+	r.Equal(float64(2), doc.Summary["files_parsed"])
+}
+
+func Test_testReportHTML(t *testing.T) {
+	r := require.New(t)
+
+	report := NewReport([]error{
+		&ValidationError{
+			Struct:     "Order",
+			Field:      "Note",
+			Tag:        "db",
+			Value:      "id",
+			Message:    "Duplicate tag value id in Order.db",
+			Suggestion: "rename it, the value is held by Order.ID",
+			Pos:        token.Position{Filename: "models/order.go", Line: 10, Column: 14},
+		},
+		&ValidationError{
+			Struct:  "Customer",
+			Field:   "Name",
+			Tag:     "json",
+			Value:   "<name>",
+			Message: "Invalid symboles <> in Customer.json.<name>",
+			Pos:     token.Position{Filename: "models/customer.go", Line: 4, Column: 14},
+		},
+		&ValidationError{
+			Struct:  "Customer",
+			Field:   "ID",
+			Tag:     "db",
+			Value:   "",
+			Message: "Tag cannot be empty Customer.db",
+			Pos:     token.Position{Filename: "models/customer.go", Line: 3, Column: 14},
+		},
+		errors.New("models/broken.go:3:22: expected '}', found 'EOF'"),
+	})
+
+	out := &bytes.Buffer{}
+	r.NoError(report.WriteHTML(out))
+
+	golden := filepath.Join("testdata", "report.golden.html")
+
+	if *update {
+		r.NoError(os.WriteFile(golden, out.Bytes(), 0644))
+	}
+
+	expected, err := os.ReadFile(golden)
+
+	r.NoError(err)
+	r.Equal(string(expected), out.String())
+	r.NotContains(out.String(), "http")
+}
+
+func Test_testReportSARIF(t *testing.T) {
+	r := require.New(t)
+
+	report := NewReport([]error{
+		&ValidationError{
+			Struct:   "Order",
+			Field:    "Note",
+			Tag:      "db",
+			Value:    "id",
+			Message:  "Duplicate tag value id in Order.db",
+			Pos:      token.Position{Filename: "models/order.go", Line: 10, Column: 14},
+			ValuePos: token.Position{Filename: "models/order.go", Offset: 120, Line: 10, Column: 19},
+			ValueEnd: token.Position{Filename: "models/order.go", Offset: 122, Line: 10, Column: 21},
+		},
+		&ValidationError{
+			Struct:  "Customer",
+			Field:   "Name",
+			Message: "Missing tag db in Customer.Name",
+			Pos:     token.Position{Filename: "models/customer.go", Line: 4, Column: 7},
+		},
+		errors.New("models/broken.go:3:22: expected '}', found 'EOF'"),
+	})
+
+	out := &bytes.Buffer{}
+	r.NoError(report.WriteSARIF(out))
+
+	doc := sarifLog{}
+	r.NoError(json.Unmarshal(out.Bytes(), &doc))
+
+	r.Equal("2.1.0", doc.Version)
+	r.Len(doc.Runs, 1)
+	r.False(doc.Runs[0].Invocations[0].ExecutionSuccessful)
+	r.Len(doc.Runs[0].Invocations[0].Notifications, 1)
+
+	results := doc.Runs[0].Results
+
+	r.Len(results, 2)
+	r.Equal("models/customer.go", results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	r.Equal(sarifRegion{StartLine: 4, StartColumn: 7}, results[0].Locations[0].PhysicalLocation.Region)
+	r.Equal("db", results[1].RuleID)
+	r.Equal(sarifRegion{StartLine: 10, StartColumn: 19, EndLine: 10, EndColumn: 21}, results[1].Locations[0].PhysicalLocation.Region)
+}
+
+func Test_testWriteReportFile(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", `package models
+
+type Customer struct {
+	ID   int    `+"`"+`db:"id"`+"`"+`
+	Name string `+"`"+`db:"name_"`+"`"+`
+}
+`)
+	defer os.RemoveAll("./models")
+
+	m := NewValidator("./models")
+	m.AddDefaultProcessors("db")
+
+	path := filepath.Join("models", "tagreport.txt")
+
+	r.ErrorIs(m.CheckReportFile(path, FormatText), ErrStaleReport)
+	r.NoError(m.WriteReportFile(path, FormatText))
+
+	content, err := os.ReadFile(path)
+
+	r.NoError(err)
+	r.Equal("customer.go\n"+
+		"  Customer\n"+
+		"    5:14: Tag cannot end on _ in  Customer.db.name_, charset [a-z0-9_, ] (suggested: name)\n"+
+		"\n"+
+		"1 findings in 1 structs across 1 files\n"+
+		"  1 from default\n", string(content))
+	r.NoError(m.CheckReportFile(path, FormatText))
+
+	//The summary of the JSON report has no duration, so it is stable as well
+	jsonPath := filepath.Join("models", "tagreport.json")
+
+	r.NoError(m.WriteReportFile(jsonPath, FormatJSON))
+	r.NoError(m.CheckReportFile(jsonPath, FormatJSON))
+
+	createFile("customer.go", `package models
+
+type Customer struct {
+	ID   int    `+"`"+`db:"id"`+"`"+`
+	Name string `+"`"+`db:"name"`+"`"+`
+}
+`)
+
+	r.ErrorIs(m.CheckReportFile(path, FormatText), ErrStaleReport)
+
+	unchanged, err := os.ReadFile(path)
+
+	r.NoError(err)
+	r.Equal(content, unchanged)
+	r.Error(m.WriteReportFile(path, Format("xml")))
+
+	matches, err := filepath.Glob(filepath.Join("models", ".tagreport.txt.*"))
+
+	r.NoError(err)
+	r.Empty(matches)
+}
+
+func Test_testWriteReportFileCached(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", `package models
+
+type Customer struct {
+	Name string `+"`"+`db:"name_"`+"`"+`
+}
+`)
+	defer os.RemoveAll("./models")
+
+	m := NewValidator("./models")
+	m.AddDefaultProcessors("db")
+	m.SetMemoryCache(true)
+
+	//The report is written on a cold cache and checked on a warm one
+	path := filepath.Join("models", "tagreport.json")
+
+	r.NoError(m.WriteReportFile(path, FormatJSON))
+	r.NoError(m.CheckReportFile(path, FormatJSON))
+	r.Equal(1, m.Stats().FilesCached)
+}
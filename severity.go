@@ -0,0 +1,113 @@
+package validator
+
+// Severity tells the findings which should fail a build apart from the ones which are only reported.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// SetSeverity sets the severity of the findings about the given tags, `*` is a reference to all tags.
+// Findings are errors by default, the ones given a severity by their processor keep it, see NewWarning.
+func (v *Validator) SetSeverity(severity Severity, tags ...string) {
+	//Copied on write, so runs in progress keep the severities they started with
+	severities := make(map[string]Severity, len(v.severities)+len(tags))
+
+	for tag, s := range v.severities {
+		severities[tag] = s
+	}
+
+	for _, tag := range tags {
+		severities[tag] = severity
+	}
+
+	v.severities = severities
+}
+
+// NewWarning wraps an error returned by a processor into a finding of warning severity.
+func NewWarning(err error) *ValidationError {
+	return &ValidationError{
+		Message:  err.Error(),
+		Severity: SeverityWarning,
+		err:      err,
+	}
+}
+
+// applySeverity sets the severity of the findings without one, from the severity set for their tag.
+func (v *Validator) applySeverity(findings []*ValidationError) {
+	for _, finding := range findings {
+		if len(finding.Severity) > 0 {
+			continue
+		}
+
+		severity, exists := v.severities[finding.Tag]
+
+		if !exists {
+			severity, exists = v.severities[AllTags]
+		}
+
+		if !exists {
+			severity = SeverityError
+		}
+
+		finding.Severity = severity
+	}
+}
+
+// Counts breaks the findings of a run down by tag, severity and processor.
+// Findings about no tag in particular, e.g. a struct declared twice, are counted under an empty tag name.
+type Counts struct {
+	Total       int
+	ByTag       map[string]int
+	BySeverity  map[Severity]int
+	ByProcessor map[string]int
+}
+
+// Counts counts the findings of the run, the ones of custom processors included.
+func (r *RunResult) Counts() Counts {
+	counts := Counts{
+		Total:       len(r.Findings),
+		ByTag:       map[string]int{},
+		BySeverity:  map[Severity]int{},
+		ByProcessor: countByProcessor(r.Findings),
+	}
+
+	for _, finding := range r.Findings {
+		counts.ByTag[finding.Tag]++
+		counts.BySeverity[finding.severity()]++
+	}
+
+	return counts
+}
+
+// FailedFor reports whether there is a finding of error severity about one of the given tags, e.g. to fail a build on db tags only.
+// Without tags, or with `*`, any error counts.
+func (r *RunResult) FailedFor(tags ...string) bool {
+	wanted := make(map[string]bool, len(tags))
+
+	for _, tag := range tags {
+		wanted[tag] = true
+	}
+
+	for _, finding := range r.Findings {
+		if finding.severity() != SeverityError {
+			continue
+		}
+
+		if len(tags) == 0 || wanted[AllTags] || wanted[finding.Tag] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// severity returns the severity of the finding, findings which weren't given one are errors.
+func (e *ValidationError) severity() Severity {
+	if len(e.Severity) == 0 {
+		return SeverityError
+	}
+
+	return e.Severity
+}
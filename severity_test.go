@@ -0,0 +1,93 @@
+package validator
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_testSeverity(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", `package models
+
+type Customer struct {
+	ID   int    `+"`"+`db:"id" json:"id" validate:"required"`+"`"+`
+	Name string `+"`"+`db:"na-me" json:"name" validate:""`+"`"+`
+	Mail string `+"`"+`db:"mail" json:"ma-il" validate:"email"`+"`"+`
+}
+`)
+	defer os.RemoveAll("./models")
+
+	legacyID := func(tag *Tag) []error {
+		if tag.GetValue() == "id" {
+			return []error{NewWarning(errors.New("id is a legacy column"))}
+		}
+
+		return nil
+	}
+
+	tests := []struct {
+		name       string
+		severities map[string]Severity
+		bySeverity map[Severity]int
+		//failed maps comma separated tag lists to the expected result of FailedFor
+		failed map[string]bool
+	}{
+		{
+			name:       "errors by default",
+			bySeverity: map[Severity]int{SeverityError: 3, SeverityWarning: 1},
+			failed:     map[string]bool{"": true, "db": true, "json": true, "validate": true, "xml": false, "*": true},
+		},
+		{
+			name:       "json warnings",
+			severities: map[string]Severity{"json": SeverityWarning},
+			bySeverity: map[Severity]int{SeverityError: 2, SeverityWarning: 2},
+			failed:     map[string]bool{"": true, "db": true, "json": false, "validate": true, "json,xml": false, "json,validate": true},
+		},
+		{
+			name:       "all warnings but db",
+			severities: map[string]Severity{AllTags: SeverityWarning, "db": SeverityError},
+			bySeverity: map[Severity]int{SeverityError: 1, SeverityWarning: 3},
+			failed:     map[string]bool{"": true, "db": true, "json": false, "validate": false, "json,validate": false},
+		},
+		{
+			name:       "all warnings",
+			severities: map[string]Severity{AllTags: SeverityWarning},
+			bySeverity: map[Severity]int{SeverityWarning: 4},
+			failed:     map[string]bool{"": false, "db": false, "*": false},
+		},
+	}
+
+	for _, test := range tests {
+		m := NewValidator(modelsPath)
+		m.AddDefaultProcessors("db", "json", "validate")
+		m.AddProcessorNamed("db", "legacy-id", legacyID)
+
+		for tag, severity := range test.severities {
+			m.SetSeverity(severity, tag)
+		}
+
+		result, err := m.Validate()
+		r.NoError(err, test.name)
+
+		counts := result.Counts()
+		r.Equal(4, counts.Total, test.name)
+		r.Equal(map[string]int{"db": 2, "json": 1, "validate": 1}, counts.ByTag, test.name)
+		r.Equal(map[string]int{"default": 3, "legacy-id": 1}, counts.ByProcessor, test.name)
+		r.Equal(test.bySeverity, counts.BySeverity, test.name)
+
+		for tags, failed := range test.failed {
+			names := []string{}
+
+			if len(tags) > 0 {
+				names = strings.Split(tags, ",")
+			}
+
+			r.Equal(failed, result.FailedFor(names...), "%v: %v", test.name, tags)
+		}
+	}
+}
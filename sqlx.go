@@ -0,0 +1,97 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// sqlxSegmentCharset is the character class of the segments of sqlx db tag names.
+var sqlxSegmentCharset = mustCharset("a-z0-9_")
+
+// AddSQLXProcessors adds the processors for the db tags of sqlx, see NewSQLXProcessor, along with the default check of empty values.
+// They replace the default processors for db tags, whose rules don't allow the dot separating a prefix.
+func (v *Validator) AddSQLXProcessors(options ...string) {
+	v.addEmptyValueProcessor("db")
+	v.AddProcessorNamed("db", "sqlx", NewSQLXProcessor(options...))
+}
+
+// NewSQLXProcessor creates a processor for the db tags of sqlx, whose names may have prefixes separated by dots
+// for the columns of joined tables, e.g. `db:"profile.avatar_url"`. Every segment must be of lowercase letters, digits and underscores,
+// ending on a letter or a digit. Options after the comma must be one of the given ones, e.g. readonly for `db:"total,readonly"`.
+// Empty names and the value `-` are left to the default processors. Duplicates are checked on the whole value, prefixes included.
+func NewSQLXProcessor(options ...string) func(tag *Tag) []error {
+	known := make(map[string]bool, len(options))
+
+	for _, option := range options {
+		known[option] = true
+	}
+
+	return func(tag *Tag) []error {
+		errs := []error{}
+		name, rest, hasOptions := strings.Cut(tag.GetValue(), ",")
+
+		if len(name) == 0 || tag.GetValue() == SkipTag {
+			return errs
+		}
+
+		segments := strings.Split(name, ".")
+		//The rules of a charset report invalid symbols and a trailing character, in that order
+		invalid, trailing := sqlxSegmentCharset.rules[0], sqlxSegmentCharset.rules[1]
+
+		//withSegment returns the value with the segment at i replaced, for suggestions
+		withSegment := func(i int, segment string) string {
+			replaced := append([]string{}, segments...)
+			replaced[i] = segment
+			value := strings.Join(replaced, ".")
+
+			if hasOptions {
+				value += "," + rest
+			}
+
+			return value
+		}
+
+		for i, segment := range segments {
+			if len(segment) == 0 {
+				errs = append(errs, fmt.Errorf("Tag value %v in %v.%v has an empty segment, prefixes are separated by a single dot",
+					name, tag.GetStructName(), tag.GetName()))
+				continue
+			}
+
+			if match := invalid.rexpr.FindString(segment); len(match) > 0 {
+				err := fmt.Errorf("Invalid symbols %v in the segment %v of %v.%v.%v", match, segment, tag.GetStructName(), tag.GetName(), name)
+				errs = append(errs, NewErrorWithSuggestion(err, withSegment(i, sqlxSegmentCharset.suggest(segment))))
+			}
+
+			if trailing.rexpr.MatchString(segment) {
+				err := fmt.Errorf("Segment %v of %v.%v.%v must end on a letter or a digit", segment, tag.GetStructName(), tag.GetName(), name)
+
+				if fixed := trailing.fix(segment); len(fixed) > 0 {
+					err = NewFixableError(err, withSegment(i, fixed))
+				}
+
+				errs = append(errs, err)
+			}
+		}
+
+		if !hasOptions {
+			return errs
+		}
+
+		for _, option := range strings.Split(rest, ",") {
+			if len(option) == 0 || known[option] {
+				continue
+			}
+
+			err := fmt.Errorf("Unknown db option %v in %v.%v", option, tag.GetStructName(), tag.GetFieldName())
+
+			if suggestion, found := nearest(option, known, 2); found {
+				err = NewErrorWithSuggestion(err, suggestion)
+			}
+
+			errs = append(errs, err)
+		}
+
+		return errs
+	}
+}
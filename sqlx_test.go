@@ -0,0 +1,102 @@
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var sqlxModel = "package models\n\ntype Order struct {\n" +
+	"ID int `db:\"id\"`\n" +
+	"Total int `db:\"total,readonly\"`\n" +
+	"Avatar string `db:\"profile.avatar_url\"`\n" +
+	"ProfileID int `db:\"profile.id\"`\n" +
+	"Name string `db:\"profile.Name_\"`\n" +
+	"City string `db:\"address..city,readonyl\"`\n" +
+	"Street string `db:\"address_.street\"`\n" +
+	"Zip string `db:\"-\"`\n" +
+	"Note string `db:\"\"`\n" +
+	"}\n"
+
+func Test_testSQLXProcessors(t *testing.T) {
+	r := require.New(t)
+
+	createFile("order.go", sqlxModel)
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddSQLXProcessors("readonly")
+
+	result, err := m.Validate()
+	r.NoError(err)
+
+	messages := []string{}
+
+	for _, finding := range result.Findings {
+		messages = append(messages, finding.Field+": "+finding.Error())
+	}
+
+	r.Equal([]string{
+		"Name: Invalid symbols N in the segment Name_ of Order.db.profile.Name_ (suggested: profile.name_)",
+		"Name: Segment Name_ of Order.db.profile.Name_ must end on a letter or a digit (suggested: profile.Name)",
+		"City: Tag value address..city in Order.db has an empty segment, prefixes are separated by a single dot",
+		"City: Unknown db option readonyl in Order.City (suggested: readonly)",
+		"Street: Segment address_ of Order.db.address_.street must end on a letter or a digit (suggested: address.street)",
+		"Note: Tag cannot be empty Order.db",
+	}, messages)
+
+	//The prefix is fixed along with the rest of the value
+	r.True(result.Findings[4].Fixable)
+
+	changed, err := m.Fix()
+	r.NoError(err)
+	r.Equal(1, changed)
+
+	content, err := os.ReadFile(filepath.Join("models", "order.go"))
+	r.NoError(err)
+	r.Contains(string(content), "`db:\"address.street\"`")
+	r.Contains(string(content), "`db:\"profile.Name\"`")
+}
+
+func Test_testSQLXDuplicates(t *testing.T) {
+	r := require.New(t)
+
+	createFile("order.go", "package models\n\ntype Order struct {\n"+
+		"ID int `db:\"id\"`\n"+
+		"ProfileID int `db:\"profile.id\"`\n"+
+		"UserID int `db:\"user.id\"`\n"+
+		"OwnerID int `db:\"user.id\"`\n"+
+		"}\n")
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddSQLXProcessors()
+
+	errs := m.Run()
+
+	//Values only clash with their prefixes
+	r.Len(errs, 1)
+	r.True(strings.HasPrefix(errs[0].Error(), "Duplicate tag value user.id in Order.db"), errs[0].Error())
+}
+
+func Test_testSQLXConfig(t *testing.T) {
+	r := require.New(t)
+
+	createFile("order.go", sqlxModel)
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	r.NoError(m.LoadConfig(strings.NewReader("processors:\n  - name: sqlx\n    args: {options: [readonly]}\n")))
+
+	errs := m.Run()
+	r.Len(errs, 6)
+	r.Equal(map[string]int{"sqlx": 5, "default": 1}, m.Summary())
+
+	m = NewValidator(modelsPath)
+	err := m.LoadConfig(strings.NewReader("processors:\n  - name: sqlx\n    args: {option: [readonly]}\n"))
+	r.Error(err)
+	r.Contains(err.Error(), "unknown argument option")
+}
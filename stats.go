@@ -0,0 +1,44 @@
+package validator
+
+import "time"
+
+// Stats holds the numbers gathered during the last run.
+// Partial is set when the run timed out, the numbers cover the work done until then.
+// FilesCached counts the parsed files whose tags were taken from the cache instead, see SetCache.
+// ValidateDuration is the part of Duration spent running the processors, after the tags were collected.
+type Stats struct {
+	FilesParsed      int            `json:"files_parsed"`
+	FilesCached      int            `json:"files_cached,omitempty"`
+	StructsFound     int            `json:"structs_found"`
+	StructsSkipped   int            `json:"structs_skipped"`
+	FieldsExcluded   int            `json:"fields_excluded"`
+	TagsCollected    map[string]int `json:"tags_collected"`
+	ProcessorsRun    int            `json:"processors_run"`
+	ErrorsProduced   int            `json:"errors_produced"`
+	Suppressed       int            `json:"suppressed"`
+	Duration         time.Duration  `json:"duration"`
+	ValidateDuration time.Duration  `json:"validate_duration"`
+	Partial          bool           `json:"partial,omitempty"`
+}
+
+func newStats() Stats {
+	return Stats{
+		TagsCollected: map[string]int{},
+	}
+}
+
+// Stats returns the statistics of the last run.
+// They are reset at the start of every run.
+func (v *Validator) Stats() Stats {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	stats := v.stats
+	stats.TagsCollected = make(map[string]int, len(v.stats.TagsCollected))
+
+	for name, count := range v.stats.TagsCollected {
+		stats.TagsCollected[name] = count
+	}
+
+	return stats
+}
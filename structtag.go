@@ -0,0 +1,265 @@
+package validator
+
+import (
+	"bytes"
+	"fmt"
+	"go/token"
+	"strconv"
+	"unicode/utf8"
+)
+
+// tagPair is a key:"value" pair of a struct tag.
+// The offsets locate the quoted value, quotes included, within the tag.
+type tagPair struct {
+	key        string
+	value      string
+	valueStart int
+	valueEnd   int
+}
+
+// scanTag splits a struct tag into its key:"value" pairs following the reflect.StructTag conventions.
+// Scanning stops at the first malformed pair, the pairs found before it are returned with an error.
+func scanTag(tag string) ([]tagPair, error) {
+	pairs := []tagPair{}
+	offset := 0
+
+	for offset < len(tag) {
+		//Skip leading space
+		for offset < len(tag) && tag[offset] == ' ' {
+			offset++
+		}
+
+		if offset == len(tag) {
+			break
+		}
+
+		//Scan to colon, a space, a quote or a control character is a syntax error
+		i := offset
+
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+
+		if i == offset || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			return pairs, fmt.Errorf("bad syntax for struct tag pair at offset %v", offset)
+		}
+
+		key := tag[offset:i]
+		start := i + 1
+
+		//Scan quoted string to find value
+		i = start + 1
+
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+
+			i++
+		}
+
+		if i >= len(tag) {
+			return pairs, fmt.Errorf("bad syntax for struct tag value at offset %v", start)
+		}
+
+		value, err := strconv.Unquote(tag[start : i+1])
+
+		if err != nil {
+			return pairs, fmt.Errorf("bad syntax for struct tag value at offset %v", start)
+		}
+
+		pairs = append(pairs, tagPair{key, value, start, i + 1})
+		offset = i + 1
+	}
+
+	return pairs, nil
+}
+
+// tagSyntaxError is the first malformed part of a struct tag, offset is a byte offset of the unquoted tag.
+type tagSyntaxError struct {
+	offset int
+	msg    string
+}
+
+// validateTagSyntax checks a struct tag the way the structtag check of go vet does.
+// It is stricter than scanTag and reflect.StructTag, the pairs must be separated by spaces.
+func validateTagSyntax(tag string) *tagSyntaxError {
+	offset := 0
+
+	for n := 0; offset < len(tag); n++ {
+		//Catches likely mistakes like `x:"foo",y:"bar"`, which reflect reads as a second key ",y"
+		if n > 0 && tag[offset] != ' ' {
+			return &tagSyntaxError{offset, `key:"value" pairs not separated by spaces`}
+		}
+
+		for offset < len(tag) && tag[offset] == ' ' {
+			offset++
+		}
+
+		if offset == len(tag) {
+			break
+		}
+
+		i := offset
+
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+
+		if i == offset {
+			return &tagSyntaxError{offset, "bad syntax for struct tag key"}
+		}
+
+		if i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			return &tagSyntaxError{i, fmt.Sprintf("key %v is not followed immediately by a colon and a quoted value", tag[offset:i])}
+		}
+
+		start := i + 1
+		i = start + 1
+
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+
+			i++
+		}
+
+		if i >= len(tag) {
+			return &tagSyntaxError{start, "bad syntax for struct tag value, the closing quote is missing"}
+		}
+
+		if _, err := strconv.Unquote(tag[start : i+1]); err != nil {
+			return &tagSyntaxError{start, "bad syntax for struct tag value"}
+		}
+
+		offset = i + 1
+	}
+
+	return nil
+}
+
+// AddStructTagSyntaxProcessor reports malformed tag literals like the structtag check of go vet, e.g. a missing closing quote
+// or a space between a key and its colon, at the offset of the problem. Keys appearing more than once are reported as well.
+// It checks the whole literal, the keys no processor was added for included, since reflect.StructTag can't read past the damage.
+func (v *Validator) AddStructTagSyntaxProcessor() {
+	v.tagSyntax = true
+}
+
+// newSyntaxError reports the first malformed part of the tag literal of a field, pos locates it if the literal was parsed.
+func (col *collector) newSyntaxError(structName, fieldName string, pos token.Position, syntaxErr *tagSyntaxError) error {
+	return &ValidationError{
+		Struct:    structName,
+		Field:     fieldName,
+		Message:   fmt.Sprintf("Malformed tag of %v.%v, %v at offset %v", structName, fieldName, syntaxErr.msg, syntaxErr.offset),
+		Pos:       pos,
+		Source:    col.source,
+		Processor: StructTagSyntaxProcessor,
+	}
+}
+
+// checkDuplicatePairs reports the keys appearing more than once in the tag of a field,
+// unless the duplicate keys check reports them already. The field is described by the given tag.
+func (col *collector) checkDuplicatePairs(pairs []tagPair, field *Tag) []error {
+	errs := []error{}
+	seen := make(map[string]bool, len(pairs))
+
+	for _, pair := range pairs {
+		if !seen[pair.key] {
+			seen[pair.key] = true
+			continue
+		}
+
+		if col.checkDuplicateKeys && (col.keys == nil || col.keys[pair.key]) {
+			continue
+		}
+
+		errs = append(errs, &ValidationError{
+			Struct:  field.GetStructName(),
+			Field:   field.GetFieldName(),
+			Tag:     pair.key,
+			Value:   pair.value,
+			Message: fmt.Sprintf("Duplicate tag key %v in %v.%v, reflect.StructTag only reads the first one", pair.key, field.GetStructName(), field.GetFieldName()),
+			Pos:     field.GetPosition(),
+			Source:  field.source,
+		})
+	}
+
+	return errs
+}
+
+// unquoteTag returns the content of a tag literal, either a raw or an interpreted string.
+func unquoteTag(literal string) (string, error) {
+	return strconv.Unquote(literal)
+}
+
+// literalOffsets maps every byte offset of the unquoted tag, and its end, to the offset within the tag literal.
+// Escape sequences map all the bytes they produce to their backslash, carriage returns dropped from raw strings are skipped.
+func literalOffsets(literal string) ([]int, error) {
+	if len(literal) < 2 {
+		return nil, fmt.Errorf("bad tag literal %v", literal)
+	}
+
+	body := literal[1 : len(literal)-1]
+	offsets := make([]int, 0, len(literal)-1)
+
+	if literal[0] == '`' {
+		for i := 0; i < len(body); i++ {
+			if body[i] != '\r' {
+				offsets = append(offsets, i+1)
+			}
+		}
+
+		return append(offsets, len(literal)-1), nil
+	}
+
+	for rest := body; len(rest) > 0; {
+		offset := len(body) - len(rest) + 1
+		value, multibyte, tail, err := strconv.UnquoteChar(rest, literal[0])
+
+		if err != nil {
+			return nil, err
+		}
+
+		size := 1
+
+		if multibyte {
+			size = utf8.RuneLen(value)
+		}
+
+		for i := 0; i < size; i++ {
+			offsets = append(offsets, offset)
+		}
+
+		rest = tail
+	}
+
+	return append(offsets, len(literal)-1), nil
+}
+
+// sourceLiteral returns the tag literal at the given offset of the source as it was written.
+// Raw strings may hold carriage returns the parsed literal doesn't have, so they are scanned up to the closing backquote.
+func sourceLiteral(src []byte, offset int, literal string) string {
+	if len(literal) == 0 || offset+len(literal) > len(src) {
+		return literal
+	}
+
+	if literal[0] != '`' {
+		return string(src[offset : offset+len(literal)])
+	}
+
+	if end := bytes.IndexByte(src[offset+1:], '`'); end >= 0 {
+		return string(src[offset : offset+end+2])
+	}
+
+	return literal
+}
+
+// quoteTag quotes a tag the same way the original literal was quoted, if possible.
+func quoteTag(tag string, original string) string {
+	if len(original) > 0 && original[0] == '`' && strconv.CanBackquote(tag) {
+		return "`" + tag + "`"
+	}
+
+	return strconv.Quote(tag)
+}
@@ -0,0 +1,120 @@
+package validator
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_testValidateTagSyntax(t *testing.T) {
+	r := require.New(t)
+
+	tests := []struct {
+		tag    string
+		offset int
+		msg    string
+	}{
+		{`json:"name" db:"name"`, -1, ""},
+		{` json:"name"  db:"name" `, -1, ""},
+		{`json:"name`, 5, "bad syntax for struct tag value, the closing quote is missing"},
+		{`json :"name"`, 4, "key json is not followed immediately by a colon and a quoted value"},
+		{`json:name`, 4, "key json is not followed immediately by a colon and a quoted value"},
+		{`json:"a",db:"b"`, 8, `key:"value" pairs not separated by spaces`},
+		{`json:"a" :"b"`, 9, "bad syntax for struct tag key"},
+		{`json:"\q"`, 5, "bad syntax for struct tag value"},
+	}
+
+	for _, test := range tests {
+		syntaxErr := validateTagSyntax(test.tag)
+
+		if test.offset < 0 {
+			r.Nil(syntaxErr, test.tag)
+			continue
+		}
+
+		r.NotNil(syntaxErr, test.tag)
+		r.Equal(test.offset, syntaxErr.offset, test.tag)
+		r.Equal(test.msg, syntaxErr.msg, test.tag)
+	}
+}
+
+func Test_testStructTagSyntaxProcessor(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", `package models
+
+type Customer struct {
+	ID      int    `+"`"+`db:"id"`+"`"+`
+	Name    string `+"`"+`db:"name" json:"name`+"`"+`
+	Email   string `+"`"+`db:"email" json :"email"`+"`"+`
+	Phone   string `+"`"+`xml:"phone" xml:"tel"`+"`"+`
+	Address string "db:\"address\" json:\"addr\\\"ess\""
+}
+`)
+	defer os.RemoveAll("./models")
+
+	//Only db tags are collected, the other keys are checked anyway
+	m := NewValidator(modelsPath)
+	m.AddStructTagSyntaxProcessor()
+	m.AddProcessor("db", func(*Tag) []error { return nil })
+
+	result, err := m.Validate()
+
+	r.NoError(err)
+	r.Len(result.Findings, 3)
+
+	messages := map[string]*ValidationError{}
+
+	for _, finding := range result.Findings {
+		messages[finding.Message] = finding
+		r.Equal(StructTagSyntaxProcessor, finding.Processor)
+	}
+
+	//The offsets are within the tag, the positions point at the problem in the source
+	missingQuote := messages["Malformed tag of Customer.Name, bad syntax for struct tag value, the closing quote is missing at offset 15"]
+	r.NotNil(missingQuote)
+	r.Equal("Name", missingQuote.Field)
+	r.Equal(5, missingQuote.Pos.Line)
+	r.Equal(33, missingQuote.Pos.Column)
+
+	space := messages["Malformed tag of Customer.Email, key json is not followed immediately by a colon and a quoted value at offset 15"]
+	r.NotNil(space)
+	r.Equal(6, space.Pos.Line)
+	r.Equal(33, space.Pos.Column)
+
+	duplicate := messages["Duplicate tag key xml in Customer.Phone, reflect.StructTag only reads the first one"]
+	r.NotNil(duplicate)
+	r.Equal("xml", duplicate.Tag)
+
+	//The duplicate keys check reports the keys it collects itself, all of them here
+	m = NewValidator(modelsPath)
+	m.AddStructTagSyntaxProcessor()
+
+	result, err = m.Validate()
+
+	r.NoError(err)
+	r.Equal(map[string]int{StructTagSyntaxProcessor: 2, DuplicateKeysProcessor: 1}, m.Summary())
+}
+
+func Test_testStructTagSyntaxProcessorTypes(t *testing.T) {
+	r := require.New(t)
+
+	//Built at runtime, go vet would reject the literal
+	customer := reflect.StructOf([]reflect.StructField{{
+		Name: "Name",
+		Type: reflect.TypeOf(""),
+		Tag:  reflect.StructTag(`db:"name",json:"name"`),
+	}})
+
+	m := NewValidator("unused")
+	m.AddStructTagSyntaxProcessor()
+
+	result, err := m.ValidateTypes(customer)
+
+	r.NoError(err)
+	r.Len(result.Findings, 1)
+	r.Equal(`Malformed tag of struct#1.Name, key:"value" pairs not separated by spaces at offset 9`, result.Findings[0].Message)
+	r.Equal(SourceReflection, result.Findings[0].Source)
+}
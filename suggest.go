@@ -0,0 +1,54 @@
+package validator
+
+import "sort"
+
+// levenshtein returns the number of single character edits turning a into b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+// nearest returns the candidate closest to value within maxDistance edits.
+// Ties are resolved alphabetically so the result is stable.
+func nearest(value string, candidates map[string]bool, maxDistance int) (string, bool) {
+	names := make([]string, 0, len(candidates))
+
+	for name := range candidates {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	best, bestDistance := "", maxDistance+1
+
+	for _, name := range names {
+		if d := levenshtein(value, name); d < bestDistance {
+			best, bestDistance = name, d
+		}
+	}
+
+	return best, bestDistance <= maxDistance
+}
@@ -0,0 +1,113 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+// The names findings of the built-in checks are counted under in Summary.
+const (
+	DuplicatesProcessor    = "duplicates"
+	DuplicateKeysProcessor = "duplicate-keys"
+	UnknownTagsProcessor   = "unknown-tags"
+	RequiredTagsProcessor  = "required-tags"
+	//DuplicateStructsProcessor reports struct names declared more than once, see Tag.GetDeclarationPos
+	DuplicateStructsProcessor = "duplicate-structs"
+	//DirectivesProcessor reports malformed directives in doc comments, see DirectivePrefix
+	DirectivesProcessor = "directives"
+	//StructTagSyntaxProcessor reports malformed tag literals, see AddStructTagSyntaxProcessor
+	StructTagSyntaxProcessor = "struct-tag-syntax"
+)
+
+// tagProcessor is a processor of single tags along with the name its findings are counted under and its priority.
+type tagProcessor struct {
+	name string
+	run  func(tag *Tag) []error
+	//runWith is set instead of run for the built-in processors reading the options of the validator, it is given the one of the run
+	runWith  func(r *Validator, tag *Tag) []error
+	priority int
+}
+
+// structProcessor is a processor of structs along with the name its findings are counted under.
+type structProcessor struct {
+	name string
+	run  func(s *StructInfo) []error
+}
+
+// packageProcessor is a processor of all tags along with the name its findings are counted under.
+type packageProcessor struct {
+	name string
+	run  func(allTags map[string][]*Tag) []error
+}
+
+// funcLiteralName matches the names the compiler gives function literals, e.g. `main.main.func1`.
+var funcLiteralName = regexp.MustCompile(`\.func\d+(\.\d+)*$`)
+
+// processorLabel names a processor added without a name after its function, without the package.
+// Function literals returned by constructors are named after the constructor, e.g. `NewMaxLengthProcessor`,
+// other ones have no name of their own, so they are numbered in the order they were added.
+func (v *Validator) processorLabel(processor interface{}) string {
+	if f := runtime.FuncForPC(reflect.ValueOf(processor).Pointer()); f != nil {
+		name := f.Name()
+		//Dots in the last element of the import path are escaped, so the first one ends the package
+		name = name[strings.LastIndex(name, "/")+1:]
+		name = strings.TrimSuffix(name[strings.Index(name, ".")+1:], "-fm")
+
+		if !funcLiteralName.MatchString(name) {
+			return name
+		}
+
+		enclosing := funcLiteralName.ReplaceAllString(name, "")
+
+		if strings.HasPrefix(enclosing[strings.LastIndex(enclosing, ".")+1:], "New") {
+			return enclosing
+		}
+	}
+
+	v.anonymousProcessors++
+
+	return fmt.Sprintf("processor #%v", v.anonymousProcessors)
+}
+
+// attributeErrors marks the findings as produced by the named processor, unless they were attributed already.
+func attributeErrors(errs []error, processor string) []error {
+	for _, err := range errs {
+		var finding *ValidationError
+
+		if errors.As(err, &finding) && len(finding.Processor) == 0 {
+			finding.Processor = processor
+		}
+	}
+
+	return errs
+}
+
+// countByProcessor counts the findings per processor name.
+func countByProcessor(findings []*ValidationError) map[string]int {
+	counts := map[string]int{}
+
+	for _, finding := range findings {
+		counts[finding.Processor]++
+	}
+
+	return counts
+}
+
+// Summary returns the number of findings of the last run per processor name, without the ones suppressed by the baseline.
+// Processors are named by AddProcessorNamed, the built-in checks have names of their own, e.g. DuplicatesProcessor.
+func (v *Validator) Summary() map[string]int {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	summary := make(map[string]int, len(v.summary))
+
+	for name, count := range v.summary {
+		summary[name] = count
+	}
+
+	return summary
+}
@@ -0,0 +1,65 @@
+package validator
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func checkNotID(tag *Tag) []error {
+	if tag.GetValue() == "id" {
+		return []error{errors.New("id is reserved")}
+	}
+
+	return nil
+}
+
+func Test_testSummary(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", `package models
+
+type Customer struct {
+	ID        int    `+"`"+`db:"id"`+"`"+`
+	Name      string `+"`"+`db:"id" json:"name"`+"`"+`
+	CreatedAt string `+"`"+`db:"created-at" db:"created_at"`+"`"+`
+}
+`)
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("db")
+	m.AddProcessorNamed("db", "too-long", NewMaxLengthProcessor(5))
+	m.AddProcessor("db", checkNotID)
+	m.AddProcessor("db", func(tag *Tag) []error { return []error{errors.New("always")} })
+	m.AddProcessor("db", NewMaxLengthProcessor(6))
+	m.AddStructProcessor("db", func(s *StructInfo) []error { return []error{errors.New("struct")} })
+	m.RequireTag("json")
+
+	r.Empty(m.Summary())
+
+	result, err := m.Validate()
+
+	r.NoError(err)
+	r.Equal(map[string]int{
+		"default":               1,
+		"too-long":              2,
+		"checkNotID":            2,
+		"processor #1":          4,
+		"NewMaxLengthProcessor": 2,
+		"processor #2":          1,
+		DuplicatesProcessor:     1,
+		DuplicateKeysProcessor:  1,
+		RequiredTagsProcessor:   2,
+	}, m.Summary())
+
+	total := 0
+
+	for _, count := range m.Summary() {
+		total += count
+	}
+
+	r.Len(result.Findings, total)
+}
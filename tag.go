@@ -1,13 +1,19 @@
 package validator
 
 import (
+	"context"
 	"fmt"
 	"go/ast"
+	"go/build"
 	"go/parser"
 	"go/token"
+	"io"
+	"io/fs"
+	"log/slog"
 	"os"
+	"path"
 	"path/filepath"
-	"regexp"
+	"sort"
 	"strings"
 	"sync"
 )
@@ -17,6 +23,27 @@ type Tag struct {
 	name       *string
 	value      *string
 	structName *string
+	fieldName  *string
+	pos        token.Position
+	valueStart token.Position
+	valueEnd   token.Position
+	//source is empty for tags parsed from files, see SourceReflection
+	source string
+	//declaration is the declaration of the struct, structs of one name declared in several places are told apart by it
+	declaration *declaration
+	exported    bool
+}
+
+// declaration identifies the declaration of a struct, the key is unique per package, file and struct.
+// It holds the doc comment of the struct along with its directives.
+type declaration struct {
+	key        string
+	pos        token.Position
+	doc        string
+	directives map[string]string
+	//pkg is the name of the declaring package, pkgPath tells packages of one name apart, it is the directory of parsed ones
+	pkg     string
+	pkgPath string
 }
 
 // GetName returns the name of the tag.
@@ -46,15 +73,116 @@ func (t *Tag) GetStructName() string {
 	return *t.structName
 }
 
-func getPackages(folder string, models ...string) map[string]*ast.Package {
-	var path string
+// GetFieldName returns the name of the field the tag belongs to.
+// Embedded fields are named after their type.
+func (t *Tag) GetFieldName() string {
+	if t == nil || t.fieldName == nil {
+		return ""
+	}
+
+	return *t.fieldName
+}
+
+// GetPosition returns the position of the tag literal in the source.
+func (t *Tag) GetPosition() token.Position {
+	if t == nil {
+		return token.Position{}
+	}
+
+	return t.pos
+}
 
-	path = os.Getenv("GOPATH")
-	path = filepath.Join(path, "src")
-	path = filepath.Join(path, folder)
+// GetValuePos returns the position of the tag value within the tag literal, without its quotes.
+// The end is the position right after the last byte of the value, both are zero if the tag doesn't come from a literal.
+func (t *Tag) GetValuePos() (start, end token.Position) {
+	if t == nil {
+		return token.Position{}, token.Position{}
+	}
+
+	return t.valueStart, t.valueEnd
+}
+
+// IsExported reports whether the field the tag belongs to is exported, embedded fields are exported if their type is.
+func (t *Tag) IsExported() bool {
+	if t == nil {
+		return false
+	}
+
+	return t.exported
+}
+
+// GetDeclarationPos returns the position of the name of the struct declaration the tag belongs to.
+// It is zero for tags read from runtime types and unnamed structs are located at their struct keyword.
+func (t *Tag) GetDeclarationPos() token.Position {
+	if t == nil || t.declaration == nil {
+		return token.Position{}
+	}
+
+	return t.declaration.pos
+}
+
+// GetPackage returns the name of the package declaring the struct the tag belongs to.
+func (t *Tag) GetPackage() string {
+	if t == nil || t.declaration == nil {
+		return ""
+	}
+
+	return t.declaration.pkg
+}
+
+// GetPackagePath returns the directory of the package declaring the struct the tag belongs to,
+// or its import path for tags read from runtime types.
+// Once a run parses more than one package the struct names are qualified with the path, e.g. billing.Invoice, see Validate.
+func (t *Tag) GetPackagePath() string {
+	if t == nil || t.declaration == nil {
+		return ""
+	}
+
+	return t.declaration.pkgPath
+}
+
+// declarationKey returns the key of the struct declaration, it is the struct name if the declaration isn't known.
+func (t *Tag) declarationKey() string {
+	if t == nil || t.declaration == nil {
+		return t.GetStructName()
+	}
+
+	return t.declaration.key
+}
+
+// fileWalker holds the settings used to list the files that should be parsed.
+type fileWalker struct {
+	ctx    build.Context
+	logger *slog.Logger
+	//fsys holds the files, they are on disk if it is nil, see NewValidatorFS
+	fsys fs.FS
+	//recursive walks the subdirectories, except for the ones the go command ignores as well
+	recursive bool
+	//followSymlinks traverses symlinked files and directories, they are skipped otherwise
+	followSymlinks bool
+	models         map[string]bool
+	//qualifiedModels holds the qualifiers of the models given as package.model, e.g. billing.invoice, by their file name
+	qualifiedModels map[string]map[string]bool
+	//skipped collects the Go files which were rejected along with the reason, if it is set
+	skipped *[]FileDiag
+	//visited holds the resolved paths of the walked directories and listed files, so none is listed twice
+	visited map[string]bool
+}
 
-	fset := token.NewFileSet()
-	modelMap := make(map[string]bool, len(models))
+// getFiles resolves the models folders and lists the files that should be parsed.
+// Every file is logged as accepted or rejected, along with the reason.
+func getFiles(folders []string, w fileWalker, models ...string) (string, []string, error) {
+	w.models = make(map[string]bool, len(models))
+	w.qualifiedModels = map[string]map[string]bool{}
+	w.visited = map[string]bool{}
+
+	//Build constraints are read from the files of fsys as well
+	if w.fsys != nil {
+		w.ctx.JoinPath = path.Join
+		w.ctx.OpenFile = func(name string) (io.ReadCloser, error) {
+			return w.fsys.Open(name)
+		}
+	}
 
 	for _, model := range models {
 		k := strings.Join([]string{
@@ -62,152 +190,826 @@ func getPackages(folder string, models ...string) map[string]*ast.Package {
 			"go",
 		}, ".")
 
-		modelMap[k] = true
+		w.models[k] = true
+
+		//A dot may qualify the model with its package as well as be part of the file name or the package path, every split is tried
+		for i, c := range model {
+			if c != '.' {
+				continue
+			}
+
+			pkg, name := strings.ToLower(model[:i]), strings.ToLower(model[i+1:])
+
+			if _, exists := w.qualifiedModels[name+".go"]; !exists {
+				w.qualifiedModels[name+".go"] = map[string]bool{}
+			}
+
+			w.qualifiedModels[name+".go"][pkg] = true
+		}
 	}
 
-	pkgs, err := parser.ParseDir(fset, path, func(f os.FileInfo) bool {
-		isNotTest := !strings.HasSuffix(f.Name(), "_test.go")
+	paths := make([]string, 0, len(folders))
+	fileNames := []string{}
 
-		if len(modelMap) > 0 {
-			_, exists := modelMap[strings.ToLower(f.Name())]
+	for _, folder := range folders {
+		resolved, err := resolveFolder(w.fsys, folder)
 
-			return isNotTest != !exists
+		if err != nil {
+			return resolved, nil, err
 		}
 
-		return isNotTest
-	}, 0)
+		w.logger.Debug("resolved models path", "path", resolved)
+		paths = append(paths, resolved)
+
+		if fileNames, err = w.walk(resolved, resolved, fileNames); err != nil {
+			return resolved, nil, err
+		}
+	}
+
+	return strings.Join(paths, ", "), fileNames, nil
+}
+
+// walk appends the files of the directory that should be parsed to fileNames.
+// Names are logged relative to the models folder root.
+func (w *fileWalker) walk(root, dir string, fileNames []string) ([]string, error) {
+	//Symlinked directories may lead back to one already walked
+	if resolved, err := evalSymlinks(w.fsys, dir); err == nil {
+		if w.visited[resolved] {
+			w.logger.Debug("directory rejected", "directory", dir, "reason", "already walked")
+			return fileNames, nil
+		}
+
+		w.visited[resolved] = true
+	}
+
+	entries, err := readDir(w.fsys, dir)
 
 	if err != nil {
-		panic(err)
+		return fileNames, err
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		fileName := joinPath(w.fsys, dir, name)
+		isDir := entry.IsDir()
+		logName := relPath(w.fsys, root, fileName)
+
+		if entry.Type()&fs.ModeSymlink != 0 {
+			//The links of an fs.FS can't be resolved, see NewValidatorFS
+			if !w.followSymlinks || w.fsys != nil {
+				w.reject(fileName, logName, "symlink")
+				continue
+			}
+
+			info, err := statFile(w.fsys, fileName)
+
+			if err != nil {
+				w.reject(fileName, logName, "broken symlink")
+				continue
+			}
+
+			isDir = info.IsDir()
+		}
+
+		if isDir {
+			//Like the go command, testdata, vendor and directories starting with . or _ are ignored
+			if !w.recursive || name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+				w.logger.Debug("file rejected", "file", logName, "reason", "directory")
+				continue
+			}
+
+			if fileNames, err = w.walk(root, fileName, fileNames); err != nil {
+				return fileNames, err
+			}
+
+			continue
+		}
+
+		if !strings.HasSuffix(name, ".go") {
+			w.logger.Debug("file rejected", "file", logName, "reason", "not a go file")
+			continue
+		}
+
+		if strings.HasSuffix(name, "_test.go") {
+			w.reject(fileName, logName, "test file")
+			continue
+		}
+
+		if len(w.models) > 0 && !w.isModel(fileName, name) {
+			w.reject(fileName, logName, "not one of the models")
+			continue
+		}
+
+		//Skip files excluded by build constraints for the target platform
+		if match, err := w.ctx.MatchFile(dir, name); err != nil || !match {
+			w.reject(fileName, logName, "build constraints")
+			continue
+		}
+
+		//The same file may be reachable through several links
+		if resolved, err := evalSymlinks(w.fsys, fileName); err == nil {
+			if w.visited[resolved] {
+				w.reject(fileName, logName, "already listed")
+				continue
+			}
+
+			w.visited[resolved] = true
+		}
+
+		w.logger.Debug("file accepted", "file", logName)
+		fileNames = append(fileNames, fileName)
 	}
 
-	if len(pkgs) == 0 {
-		panic(fmt.Errorf("No structs found at %v", path))
+	return fileNames, nil
+}
+
+// isModel reports whether the file is one of the models, a qualified model only matches the file of the packages
+// whose directory ends with the qualifier, see packageQualifiers.
+func (w *fileWalker) isModel(fileName, name string) bool {
+	if w.models[strings.ToLower(name)] {
+		return true
 	}
 
-	return pkgs
+	packages, exists := w.qualifiedModels[strings.ToLower(name)]
+
+	if !exists {
+		return false
+	}
+
+	for qualifier := range packages {
+		if matchesQualifier(filepath.Dir(fileName), qualifier) {
+			return true
+		}
+	}
+
+	return false
 }
 
-func getTags(tagNames []string, packages map[string]*ast.Package) map[string][]*Tag {
+// reject logs a rejected file and records it as skipped if it is a Go file, a symlink is recorded whatever it points at.
+func (w *fileWalker) reject(fileName, logName, reason string) {
+	w.logger.Debug("file rejected", "file", logName, "reason", reason)
 
-	concatNames := strings.Join(tagNames, "|")
+	if w.skipped != nil {
+		*w.skipped = append(*w.skipped, FileDiag{Path: fileName, Status: FileSkipped, Reason: reason})
+	}
+}
 
-	for _, name := range tagNames {
-		if name == AllTags {
-			concatNames = "[a-z0-9_]+"
-			break
+// resolvePath finds the models folder.
+// Absolute paths and paths existing relative to the working directory are used as they are,
+// otherwise it is an import path looked up in the src directory of the first GOPATH element holding it.
+// The default GOPATH of the go command is used if it is unset.
+func resolvePath(folder string) (string, error) {
+	//Drive letter paths, e.g. C:\models, are absolute on Windows only
+	if filepath.IsAbs(folder) || len(filepath.VolumeName(folder)) > 0 {
+		if info, err := os.Stat(folder); err != nil || !info.IsDir() {
+			return folder, fmt.Errorf("Models folder %v not found", folder)
 		}
+
+		return filepath.Clean(folder), nil
 	}
 
-	var dbRegex = regexp.MustCompile(
-		strings.Join([]string{
-			"(",
-			concatNames,
-			")",
-			`[ ]*:[ ]*"([^"]*)"`},
-			"",
-		),
-	)
+	if info, err := os.Stat(folder); err == nil && info.IsDir() {
+		return filepath.Abs(folder)
+	}
 
-	tagChans := []<-chan *Tag{}
-	tags := map[string][]*Tag{}
+	gopath := os.Getenv("GOPATH")
 
-	for _, pkg := range packages {
-		for _, file := range pkg.Files {
-			tagChan := collecFields(file, dbRegex)
-			tagChans = append(tagChans, tagChan)
+	if len(gopath) == 0 {
+		home, err := os.UserHomeDir()
+
+		if err != nil {
+			return "", fmt.Errorf("GOPATH is not set and there is no home directory: %w", err)
 		}
+
+		gopath = filepath.Join(home, "go")
 	}
 
-	t := multiplex(tagChans...)
+	probed := []string{}
 
-Loop:
-	for {
-		select {
-		case tag, ok := <-t:
-			if !ok {
-				break Loop
-			}
+	for _, root := range filepath.SplitList(gopath) {
+		if len(root) == 0 {
+			continue
+		}
+
+		path := filepath.Join(root, "src", folder)
+
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			return path, nil
+		}
+
+		probed = append(probed, path)
+	}
+
+	return "", fmt.Errorf("Models folder %v not found, looked in %v", folder, strings.Join(probed, ", "))
+}
 
-			tags[tag.GetStructName()] = append(tags[tag.GetStructName()], tag)
+// tagKeys builds the set of tag keys to collect, nil stands for all keys.
+func tagKeys(tagNames []string) map[string]bool {
+	keys := make(map[string]bool, len(tagNames))
+
+	for _, name := range tagNames {
+		if name == AllTags {
+			return nil
 		}
+
+		keys[name] = true
 	}
 
-	return tags
+	return keys
+}
+
+// collection holds the outcome of parsing the model files and collecting their tags.
+type collection struct {
+	packages map[string]*ast.Package
+	tags     map[string][]*Tag
+	errs     []error
+	findings []error
+	//warnings are problems with the settings, e.g. a struct pattern matching nothing
+	warnings       []error
+	fieldsExcluded int
+	//filesCached counts the files whose tags were taken from the cache
+	filesCached int
+	//files holds the outcome of every file given to getTags
+	files []FileDiag
+}
+
+// collector holds the settings used to collect the tags of a file.
+type collector struct {
+	fset *token.FileSet
+	//keys are the keys of the tags to collect, all keys are collected if it is nil
+	keys map[string]bool
+	//knownTags enables reporting the tag keys missing from it, unless they are in allowedTags
+	knownTags   map[string]bool
+	allowedTags map[string]bool
+	//checkDuplicateKeys reports a key appearing more than once in one tag literal
+	checkDuplicateKeys bool
+	//checkSyntax reports malformed tag literals whatever their keys, see AddStructTagSyntaxProcessor
+	checkSyntax bool
+	//excludeFields are glob patterns of the field names which are left out entirely
+	excludeFields []string
+	//skipUnexported are the tag keys which are left out on unexported fields, `*` stands for all keys
+	skipUnexported map[string]bool
+	//requiredTags are the tag keys every field must have
+	requiredTags []string
+	//includeLocalStructs walks function bodies for struct types declared in them
+	includeLocalStructs bool
+	logger              *slog.Logger
+	//source marks the collected tags, it is empty for tags parsed from files
+	source   string
+	messages messageTemplates
+
+	//qualifiers prefix the struct names with the path of their package by its directory, see packageQualifiers
+	qualifiers map[string]string
+	//fsys holds the files, they are on disk if it is nil
+	fsys fs.FS
+
+	//cache holds what was collected from files before, cacheSettings identifies the settings above for it
+	cache         *fileCache
+	cacheSettings string
 }
 
-func multiplex(cs ...<-chan *Tag) <-chan *Tag {
+// getTags parses the given files with a bounded number of workers and collects their tags.
+// Each file's AST is dropped as soon as its tags are collected unless retainAST is set,
+// in which case the files are merged into packages.
+// Files that fail to parse are left out and their errors are returned.
+// The workers stop once the context is done or the results are no longer consumed, its error is returned then.
+func getTags(ctx context.Context, col *collector, fileNames []string, concurrency int, retainAST bool) (collection, error) {
+	type parsedFile struct {
+		name     string
+		file     *ast.File
+		tags     []*Tag
+		findings []error
+		excluded int
+		cached   bool
+		err      error
+	}
+
+	//Cancelled on return, so no worker is left blocked if the results stop being consumed, e.g. on a panic
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	queue := make(chan string)
+	results := make(chan parsedFile, concurrency)
+
+	//send hands a result over unless the consumer is gone
+	send := func(result parsedFile) bool {
+		select {
+		case results <- result:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
 	var wg sync.WaitGroup
-	out := make(chan *Tag, 50*len(cs))
+	wg.Add(concurrency)
 
-	output := func(c <-chan *Tag) {
-		defer wg.Done()
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer wg.Done()
 
-		for {
-			select {
-			case tag := <-c:
-				if tag == nil {
-					return
+			for fileName := range queue {
+				var info os.FileInfo
+
+				//Unchanged files aren't parsed again, the AST isn't cached though
+				if col.cache != nil && !retainAST {
+					if stat, err := os.Stat(fileName); err == nil {
+						info = stat
+
+						if tags, findings, excluded, hit := col.cache.get(col, fileName, info); hit {
+							if !send(parsedFile{name: fileName, tags: tags, findings: findings, excluded: excluded, cached: true}) {
+								return
+							}
+
+							continue
+						}
+					}
+				}
+
+				//token.FileSet is safe for concurrent use, so all workers share one
+				src, err := readFile(col.fsys, fileName)
+				var file *ast.File
+
+				if err == nil {
+					file, err = parser.ParseFile(col.fset, fileName, src, parser.ParseComments)
+				}
+
+				if err != nil {
+					if !send(parsedFile{name: fileName, err: err}) {
+						return
+					}
+
+					continue
+				}
+
+				result := parsedFile{name: fileName}
+				result.tags, result.findings, result.excluded = col.collecFields(file, src)
+
+				if info != nil {
+					col.cache.put(col, fileName, info, result.tags, result.findings, result.excluded)
+				}
+
+				if retainAST {
+					result.file = file
 				}
 
-				out <- tag
+				if !send(result) {
+					return
+				}
 			}
-		}
+		}()
 	}
 
-	wg.Add(len(cs))
+	go func() {
+		defer close(queue)
 
-	for _, c := range cs {
-		go output(c)
-	}
+		for _, fileName := range fileNames {
+			select {
+			case queue <- fileName:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
 
 	go func() {
 		wg.Wait()
-		close(out)
+		close(results)
 	}()
 
-	return out
-}
+	c := collection{
+		tags:     map[string][]*Tag{},
+		errs:     []error{},
+		findings: []error{},
+	}
+
+	if retainAST {
+		c.packages = map[string]*ast.Package{}
+	}
+
+	for result := range results {
+		if err := ctx.Err(); err != nil {
+			return c, err
+		}
+
+		if result.err != nil {
+			c.errs = append(c.errs, result.err)
+			c.files = append(c.files, FileDiag{Path: result.name, Status: FileFailed, Err: result.err})
+			continue
+		}
+
+		c.files = append(c.files, FileDiag{Path: result.name, Status: FileParsed, Cached: result.cached})
+
+		if result.cached {
+			c.filesCached++
+			col.logger.Debug("file cached", "file", result.name, "tags", len(result.tags))
+		} else {
+			col.logger.Debug("file parsed", "file", result.name, "tags", len(result.tags))
+		}
+
+		for _, tag := range result.tags {
+			c.tags[tag.GetStructName()] = append(c.tags[tag.GetStructName()], tag)
+		}
+
+		c.findings = append(c.findings, result.findings...)
+		c.fieldsExcluded += result.excluded
+
+		if result.file == nil {
+			continue
+		}
+
+		pkg, exists := c.packages[result.file.Name.Name]
+
+		if !exists {
+			pkg = &ast.Package{
+				Name:  result.file.Name.Name,
+				Files: map[string]*ast.File{},
+			}
+			c.packages[result.file.Name.Name] = pkg
+		}
+
+		pkg.Files[result.name] = result.file
+	}
+
+	//Files arrive in any order, the tags of a struct are kept in declaration order
+	for _, structTags := range c.tags {
+		sort.SliceStable(structTags, func(i, j int) bool {
+			a, b := structTags[i].GetPosition(), structTags[j].GetPosition()
+
+			if a.Filename != b.Filename {
+				return a.Filename < b.Filename
+			}
+
+			return a.Offset < b.Offset
+		})
+	}
 
-func collecFields(file *ast.File, dbRegex *regexp.Regexp) <-chan *Tag {
+	return c, ctx.Err()
+}
 
-	tagChan := make(chan *Tag, 50)
+// collecFields collects the tags of all struct fields in the file, src is its source.
+// Problems found in the tag literals themselves are returned as findings, along with the number of excluded fields.
+func (col *collector) collecFields(file *ast.File, src []byte) ([]*Tag, []error, int) {
+	tags := []*Tag{}
+	findings := []error{}
+	excluded := 0
 	var structName *string
+	var decl *declaration
+	var inspect func(node ast.Node) bool
 
-	go func() {
-		ast.Inspect(file, func(node ast.Node) bool {
-			switch x := node.(type) {
-			case *ast.TypeSpec:
-				//Get the struct name and end pos
-				structName = &x.Name.Name
-			case *ast.StructType:
-				//Extract all db tags from the struct fields
-				for _, field := range x.Fields.List {
-					if field.Tag != nil {
-						matches := dbRegex.FindAllStringSubmatch(field.Tag.Value, -1)
-						if len(matches) > 0 {
-							for _, matchTags := range matches {
-								tagChan <- &Tag{
-									&matchTags[1],
-									&matchTags[2],
-									structName,
-								}
+	//prefix qualifies the struct names with the package
+	prefix := ""
+
+	if qualifier, exists := col.qualifiers[filepath.Dir(col.fset.Position(file.Package).Filename)]; exists {
+		prefix = qualifier + "."
+	}
+
+	//funcName is set while walking a function body, local counts its unnamed struct types
+	funcName := ""
+	local := 0
+	named := false
+	//typeDoc is the doc comment of an ungrouped type declaration, it documents its only type
+	var typeDoc *ast.CommentGroup
+
+	inspect = func(node ast.Node) bool {
+		switch x := node.(type) {
+		case *ast.TypeSpec:
+			//Get the struct name, generic structs are named without their type parameters
+			name := x.Name.Name
+
+			if len(funcName) > 0 {
+				name = funcName + "." + name
+			}
+
+			name = prefix + name
+			structName = &name
+			doc := x.Doc
+
+			if doc == nil {
+				doc = typeDoc
+			}
+
+			var malformed []string
+			decl, malformed = col.newDeclaration(file, name, x.Name.Pos(), doc)
+
+			if _, isStruct := x.Type.(*ast.StructType); isStruct && len(malformed) > 0 {
+				findings = append(findings, newDirectivesError(name, decl, malformed))
+			}
+
+			//Constraints may hold struct types of their own, so only the declared type is walked
+			named = true
+			ast.Inspect(x.Type, inspect)
+			named = false
+
+			return false
+		case *ast.StructType:
+			//Unnamed struct types in function bodies are numbered, e.g. `rows := []struct{...}{}`
+			if len(funcName) > 0 && !named {
+				local++
+				name := fmt.Sprintf("%v%v.local#%v", prefix, funcName, local)
+				structName = &name
+				decl, _ = col.newDeclaration(file, name, x.Pos(), nil)
+			}
+
+			//Extract all db tags from the struct fields
+			for _, field := range x.Fields.List {
+				pos := col.fset.Position(field.Pos())
+				pairs := []tagPair{}
+				var valuePos func(pair tagPair) (token.Position, token.Position)
+				var syntaxErr *tagSyntaxError
+				var syntaxPos token.Position
+
+				if field.Tag != nil {
+					tagPos := field.Tag.Pos()
+					pos = col.fset.Position(tagPos)
+
+					//Malformed literals keep the pairs found before the problem
+					if tag, err := unquoteTag(field.Tag.Value); err == nil {
+						pairs, _ = scanTag(tag)
+						//The parser drops carriage returns from raw strings, the offsets are taken from the source
+						offsets, _ := literalOffsets(sourceLiteral(src, pos.Offset, field.Tag.Value))
+
+						//The value offsets are within the unquoted tag, they are translated to the literal without the quotes of the value
+						valuePos = func(pair tagPair) (token.Position, token.Position) {
+							if pair.valueEnd >= len(offsets) {
+								return token.Position{}, token.Position{}
+							}
+
+							return col.fset.Position(tagPos + token.Pos(offsets[pair.valueStart+1])), col.fset.Position(tagPos + token.Pos(offsets[pair.valueEnd-1]))
+						}
+
+						if col.checkSyntax {
+							if syntaxErr = validateTagSyntax(tag); syntaxErr != nil && syntaxErr.offset < len(offsets) {
+								syntaxPos = col.fset.Position(tagPos + token.Pos(offsets[syntaxErr.offset]))
 							}
 						}
 					}
 				}
 
-			case *ast.FuncDecl:
-				return false
-			case *ast.ValueSpec:
+				//Fields declared together share the tag, e.g. `A, B int`
+				for _, fieldName := range fieldNames(field) {
+					if col.isExcluded(fieldName) || decl.ignores(fieldName) {
+						excluded++
+						continue
+					}
+
+					fieldTags, fieldFindings := col.collectField(structName, decl, fieldName, pairs, pos, valuePos)
+					tags = append(tags, fieldTags...)
+					findings = append(findings, fieldFindings...)
+
+					if syntaxErr != nil {
+						findings = append(findings, col.newSyntaxError(*structName, fieldName, syntaxPos, syntaxErr))
+					}
+				}
+			}
+
+		case *ast.GenDecl:
+			typeDoc = nil
+
+			if x.Tok == token.TYPE && !x.Lparen.IsValid() {
+				typeDoc = x.Doc
+			}
+
+			return true
+		case *ast.FuncDecl:
+			if !col.includeLocalStructs || x.Body == nil {
 				return false
 			}
 
+			funcName = funcDeclName(x)
+			local = 0
+			ast.Inspect(x.Body, inspect)
+			funcName = ""
+
+			return false
+		case *ast.ValueSpec:
+			//Variables declared in function bodies may hold struct types
+			return len(funcName) > 0
+		}
+
+		return true
+	}
+
+	ast.Inspect(file, inspect)
+
+	return tags, findings, excluded
+}
+
+// newDeclaration describes the declaration of a struct in the file at the given position, doc is its doc comment.
+// The malformed directives of the comment are returned along with it.
+func (col *collector) newDeclaration(file *ast.File, structName string, pos token.Pos, doc *ast.CommentGroup) (*declaration, []string) {
+	position := col.fset.Position(pos)
+	directives, malformed := parseDirectives(doc)
+
+	return &declaration{
+		key:        file.Name.Name + ":" + position.Filename + ":" + structName,
+		pos:        position,
+		doc:        doc.Text(),
+		directives: directives,
+		pkg:        file.Name.Name,
+		pkgPath:    filepath.Dir(position.Filename),
+	}, malformed
+}
+
+// collectField collects the tags of a field from the pairs of its tag literal and checks the literal itself.
+// valuePos locates the value of a pair in the source, it is nil if there is no source.
+func (col *collector) collectField(structName *string, decl *declaration, fieldName string, pairs []tagPair, pos token.Position,
+	valuePos func(pair tagPair) (token.Position, token.Position)) ([]*Tag, []error) {
+	fieldTags := make([]*Tag, 0, len(pairs))
+	findings := []error{}
+	exported := token.IsExported(fieldName)
+
+	for i := range pairs {
+		//Keys are matched exactly, e.g. `mydb:"x"` is not a db tag
+		if col.keys != nil && !col.keys[pairs[i].key] {
+			continue
+		}
+
+		if !exported && (col.skipUnexported[pairs[i].key] || col.skipUnexported[AllTags]) {
+			continue
+		}
+
+		tag := &Tag{
+			name:       &pairs[i].key,
+			value:      &pairs[i].value,
+			structName: structName,
+			fieldName:  &fieldName,
+			pos:        pos,
+			source:     col.source,
+
+			declaration: decl,
+			exported:    exported,
+		}
+
+		if valuePos != nil {
+			tag.valueStart, tag.valueEnd = valuePos(pairs[i])
+		}
+
+		fieldTags = append(fieldTags, tag)
+	}
+
+	descriptor := &Tag{
+		structName: structName,
+		fieldName:  &fieldName,
+		pos:        pos,
+		source:     col.source,
+	}
+
+	if col.checkDuplicateKeys {
+		findings = append(findings, attributeErrors(checkDuplicateKeys(fieldTags), DuplicateKeysProcessor)...)
+	}
+
+	if col.checkSyntax {
+		findings = append(findings, attributeErrors(col.checkDuplicatePairs(pairs, descriptor), StructTagSyntaxProcessor)...)
+	}
+
+	if col.knownTags != nil {
+		findings = append(findings, attributeErrors(col.checkUnknownTags(pairs, descriptor), UnknownTagsProcessor)...)
+	}
+
+	if len(col.requiredTags) > 0 {
+		findings = append(findings, attributeErrors(col.checkRequiredTags(pairs, descriptor), RequiredTagsProcessor)...)
+	}
+
+	return fieldTags, findings
+}
+
+// funcDeclName returns the name of a function, methods are prefixed with their receiver type, e.g. `Customer.Load`.
+func funcDeclName(decl *ast.FuncDecl) string {
+	if decl.Recv == nil || len(decl.Recv.List) == 0 {
+		return decl.Name.Name
+	}
+
+	//The receiver is named after its type, like an embedded field
+	return fieldNames(&ast.Field{Type: decl.Recv.List[0].Type})[0] + "." + decl.Name.Name
+}
+
+// isExcluded reports whether the field name matches one of the excluded field patterns.
+func (col *collector) isExcluded(fieldName string) bool {
+	for _, pattern := range col.excludeFields {
+		if ok, _ := path.Match(pattern, fieldName); ok {
 			return true
+		}
+	}
+
+	return false
+}
+
+// checkDuplicateKeys reports tag keys appearing more than once on a field.
+// Only the first value is seen by reflect.StructTag, the others are silently ignored.
+func checkDuplicateKeys(fieldTags []*Tag) []error {
+	errs := []error{}
+	seen := make(map[string]*Tag, len(fieldTags))
+
+	for _, t := range fieldTags {
+		first, exists := seen[t.GetName()]
+
+		if !exists {
+			seen[t.GetName()] = t
+			continue
+		}
+
+		errs = append(errs, newValidationError(t, fmt.Errorf("Duplicate tag key %v in %v.%v with values %v and %v",
+			t.GetName(), t.GetStructName(), t.GetFieldName(), first.GetValue(), t.GetValue())))
+	}
+
+	return errs
+}
+
+// checkUnknownTags reports the keys of a tag which are neither known nor allowed.
+// The field is described by the given tag.
+func (col *collector) checkUnknownTags(pairs []tagPair, field *Tag) []error {
+	errs := []error{}
+
+	for _, pair := range pairs {
+		if col.knownTags[pair.key] || col.allowedTags[pair.key] {
+			continue
+		}
+
+		err := &ValidationError{
+			Struct:  field.GetStructName(),
+			Field:   field.GetFieldName(),
+			Tag:     pair.key,
+			Value:   pair.value,
+			Message: fmt.Sprintf("Unknown tag %v in %v.%v", pair.key, field.GetStructName(), field.GetFieldName()),
+			Pos:     field.GetPosition(),
+			Source:  field.source,
+		}
+
+		if known, found := nearest(pair.key, col.knownTags, 2); found {
+			err.Suggestion = known
+		}
+
+		errs = append(errs, err)
+	}
+
+	return errs
+}
+
+// checkRequiredTags reports the required tag keys missing on a field.
+// The field is described by the given tag.
+func (col *collector) checkRequiredTags(pairs []tagPair, field *Tag) []error {
+	errs := []error{}
+
+	for _, key := range col.requiredTags {
+		found := false
+
+		for _, pair := range pairs {
+			if pair.key == key {
+				found = true
+				break
+			}
+		}
+
+		if found {
+			continue
+		}
+
+		err := col.messages.newError(MessageMissingTag, MessageData{
+			Struct: field.GetStructName(),
+			Field:  field.GetFieldName(),
+			Tag:    key,
 		})
+		err.Struct = field.GetStructName()
+		err.Field = field.GetFieldName()
+		err.Tag = key
+		err.Pos = field.GetPosition()
+		err.Source = field.source
+		errs = append(errs, err)
+	}
 
-		tagChan <- nil
-	}()
+	return errs
+}
 
-	return tagChan
+// fieldNames returns the names of a struct field, embedded fields are named after their type.
+func fieldNames(field *ast.Field) []string {
+	if len(field.Names) > 0 {
+		names := make([]string, 0, len(field.Names))
+
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+		}
+
+		return names
+	}
+
+	expr := field.Type
+
+	for {
+		switch x := expr.(type) {
+		case *ast.StarExpr:
+			expr = x.X
+		case *ast.SelectorExpr:
+			return []string{x.Sel.Name}
+		case *ast.IndexExpr:
+			//Instantiated generic types, e.g. Page[Customer]
+			expr = x.X
+		case *ast.IndexListExpr:
+			expr = x.X
+		case *ast.Ident:
+			return []string{x.Name}
+		default:
+			return []string{""}
+		}
+	}
 }
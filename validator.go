@@ -1,30 +1,86 @@
 package validator
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"go/ast"
-	"regexp"
+	"go/build"
+	"go/token"
+	"io/fs"
+	"log/slog"
+	"runtime"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 )
 
 // AllTags can be used to validate all tags
 const AllTags = "*"
 
-var defaultRegexRules = map[string]*regexp.Regexp{
-	//allowed symbols in a tag
-	"Invalid symboles %v in %v.%v.%v": regexp.MustCompile(`[^a-z0-9_, ]+`),
-	//allowed symbols of the end of a tag
-	"Tag cannot end on %v in  %v.%v.%v": regexp.MustCompile(`[^a-z0-9]$`),
-}
+// SkipTag is the conventional tag value of fields that should be skipped, e.g. `json:"-"`
+const SkipTag = "-"
 
 // Validator holds information about the parsed models
 type Validator struct {
-	packages        map[string]*ast.Package
-	tags            map[string][]*Tag
-	processors      map[string][]func(tag *Tag) []error
-	path            string
-	allowDuplicates bool
+	packages             map[string]*ast.Package
+	fset                 *token.FileSet
+	tags                 map[string][]*Tag
+	processors           map[string][]tagProcessor
+	structProcessors     map[string][]structProcessor
+	groupProcessors      []groupProcessor
+	packageProcessors    []packageProcessor
+	anonymousProcessors  int
+	path                 string
+	allowDuplicates      bool
+	skipDashTags         bool
+	duplicateKeys        bool
+	tagSyntax            bool
+	buildContext         build.Context
+	concurrency          int
+	processorConcurrency int
+	retainAST            bool
+	stats                Stats
+	findings             []*ValidationError
+	files                []FileDiag
+	qualifiers           map[string]string
+	baseline             map[BaselineEntry]int
+	staleBaseline        []BaselineEntry
+	knownTags            map[string]bool
+	allowedTags          map[string]bool
+	charsets             map[string]*charset
+	includeStructs       []string
+	excludeStructs       []string
+	excludeFields        []string
+	skipUnexported       map[string]bool
+	requiredTags         []string
+	localStructs         bool
+	failFastOnPanic      bool
+	allowEmptyValue      map[string]bool
+	logger               *slog.Logger
+	reportUnusedTags     bool
+	errorOnUnusedTag     bool
+	recursive            bool
+	followSymlinks       bool
+	extraPaths           []string
+	fsys                 fs.FS
+	allowDuplicateValues map[string]bool
+	fullDuplicates       bool
+	timeout              time.Duration
+	summary              map[string]int
+	coverage             map[string]TagCoverage
+	//messages are the message templates set with SetMessageTemplate, they are replaced on write
+	messages messageTemplates
+	//cache is shared by the snapshots, see SetCache
+	cache      *fileCache
+	severities map[string]Severity
+	//keysCache holds the keys to collect for the sorted tag names in keysCacheID, it is reused while they don't change
+	keysCache   map[string]bool
+	keysCacheID string
+	keysCached  bool
+	//mu guards the state of the last run, every run works on its own snapshot of the validator and publishes its state at the end
+	mu *sync.Mutex
 }
 
 // AddDefaultProcessors provides some basic processors that will validate the given model tags.
@@ -37,42 +93,98 @@ func (v *Validator) AddDefaultProcessors(tags ...string) {
 	}
 
 	for _, tagStr := range tags {
-		v.processors[tagStr] = append(v.processors[tagStr], func(tag *Tag) []error {
+		v.addOptionsProcessor(tagStr, "default", PriorityDefault, func(r *Validator, tag *Tag) []error {
 			errs := []error{}
 
-			for msg, rexpr := range defaultRegexRules {
-				match := rexpr.FindString(tag.GetValue())
+			if r.isSkipped(tag) {
+				return errs
+			}
+
+			for _, rule := range r.charsetFor(tag.GetName()).rules {
+				match := rule.rexpr.FindString(tag.GetValue())
 
-				if len(match) > 0 {
-					errs = append(errs, fmt.Errorf(msg, match, tag.GetStructName(), tag.GetName(), tag.GetValue()))
+				if len(match) == 0 {
+					continue
 				}
-			}
 
-			return errs
-		})
+				err := r.messages.newError(rule.kind, MessageData{
+					Struct:  tag.GetStructName(),
+					Field:   tag.GetFieldName(),
+					Tag:     tag.GetName(),
+					Value:   tag.GetValue(),
+					Match:   match,
+					Charset: r.charsetFor(tag.GetName()).class,
+				})
 
-		v.processors[tagStr] = append(v.processors[tagStr], func(tag *Tag) []error {
-			errs := []error{}
+				if rule.fix != nil {
+					if replacement := rule.fix(tag.GetValue()); len(replacement) > 0 {
+						err.Suggestion = replacement
+						err.Fixable = true
+						err.Replacement = replacement
+					}
+				} else if rule.suggest != nil {
+					if suggestion := rule.suggest(tag.GetValue()); suggestion != tag.GetValue() {
+						err.Suggestion = suggestion
+					}
+				}
 
-			if len(tag.GetValue()) == 0 {
-				errs = append(errs, fmt.Errorf("Tag cannot be empty %v.%v", tag.GetStructName(), tag.GetName()))
+				errs = append(errs, err)
 			}
 
 			return errs
 		})
+
+		v.addEmptyValueProcessor(tagStr)
 	}
 }
 
-// checkForDuplicates validates duplicate tag values
-func checkForDuplicates(t *Tag, fieldsCache map[string]bool) []error {
+// addEmptyValueProcessor adds the default check of empty values and names for the given tag.
+// An empty value is the root cause of what the other processors would report, so they are skipped.
+func (v *Validator) addEmptyValueProcessor(tag string) {
+	v.addOptionsProcessor(tag, "default", PriorityPrerequisite, func(r *Validator, tag *Tag) []error {
+		errs := []error{}
+		name, _, _ := strings.Cut(tag.GetValue(), ",")
+		data := MessageData{
+			Struct: tag.GetStructName(),
+			Field:  tag.GetFieldName(),
+			Tag:    tag.GetName(),
+			Value:  tag.GetValue(),
+		}
+
+		if len(tag.GetValue()) == 0 {
+			return Stop(append(errs, r.messages.newError(MessageEmptyTag, data)))
+		} else if len(name) == 0 && !r.allowEmptyValue[tag.GetName()] {
+			return Stop(append(errs, r.messages.newError(MessageEmptyName, data)))
+		}
+
+		return errs
+	})
+}
+
+// isSkipped reports whether the tag marks its field as skipped and its value shouldn't be checked.
+func (v *Validator) isSkipped(tag *Tag) bool {
+	return v.skipDashTags && tag.GetValue() == SkipTag
+}
+
+// checkForDuplicates validates duplicate tag values, values clash within the given scope, e.g. a struct declaration.
+func checkForDuplicates(t *Tag, scope string, fieldsCache map[string]*Tag, messages messageTemplates) []error {
 	errs := []error{}
-	cacheKey := strings.Join([]string{t.GetStructName(), t.GetName(), t.GetValue()}, ".")
+	cacheKey := strings.Join([]string{scope, t.GetName(), t.GetValue()}, ".")
+
+	if holder, exist := fieldsCache[cacheKey]; exist {
+		err := messages.newError(MessageDuplicate, MessageData{
+			Struct: t.GetStructName(),
+			Field:  t.GetFieldName(),
+			Tag:    t.GetName(),
+			Value:  t.GetValue(),
+		})
+		err.Suggestion = fmt.Sprintf("rename it, the value is held by %v.%v", holder.GetStructName(), holder.GetFieldName())
+		errs = append(errs, err)
 
-	if _, exist := fieldsCache[cacheKey]; exist {
-		errs = append(errs, fmt.Errorf("Duplicate tag value %v in %v.%v", t.GetValue(), t.GetStructName(), t.GetName()))
+		return errs
 	}
 
-	fieldsCache[cacheKey] = true
+	fieldsCache[cacheKey] = t
 
 	return errs
 }
@@ -81,75 +193,886 @@ func (v *Validator) setPath(path string) {
 	v.path = path
 }
 
+// SetPath changes the models folder, e.g. to override the path of a config given to LoadConfig.
+func (v *Validator) SetPath(path string) {
+	v.setPath(path)
+}
+
+// SetSkipDashTags sets a flag if tags with the value `-` are skipped by the default processors and the duplicates check.
+// It is enabled by default, since `-` conventionally means the field is skipped.
+func (v *Validator) SetSkipDashTags(skipDashTags bool) {
+	v.skipDashTags = skipDashTags
+}
+
+// SetCheckDuplicateKeys sets a flag if a tag key appearing more than once on a field is reported, e.g. `json:"a" json:"b"`.
+// It is enabled by default.
+func (v *Validator) SetCheckDuplicateKeys(checkDuplicateKeys bool) {
+	v.duplicateKeys = checkDuplicateKeys
+}
+
 // SetAllowDuplicates sets a flag if duplicates are allowed or not.
 // This will determine whether the duplicates check will be run.
 func (v *Validator) SetAllowDuplicates(allowDuplicates bool) {
 	v.allowDuplicates = allowDuplicates
 }
 
+// AllowDuplicateValues skips the duplicate values check for the tags of the given names only.
+func (v *Validator) AllowDuplicateValues(tags ...string) {
+	if v.allowDuplicateValues == nil {
+		v.allowDuplicateValues = map[string]bool{}
+	}
+
+	for _, tag := range tags {
+		v.allowDuplicateValues[tag] = true
+	}
+}
+
+// AddPaths adds model folders validated along with the one given to NewValidator.
+func (v *Validator) AddPaths(paths ...string) {
+	v.extraPaths = append(v.extraPaths, paths...)
+}
+
+// SetBuildContext sets the target platform and build tags used to decide which files are parsed.
+// Files excluded by their name suffix (e.g. `_windows.go`) or a `//go:build` constraint are skipped.
+// By default the host platform is used.
+func (v *Validator) SetBuildContext(goos, goarch string, tags []string) {
+	ctx := build.Default
+	ctx.GOOS = goos
+	ctx.GOARCH = goarch
+	ctx.BuildTags = tags
+	v.buildContext = ctx
+}
+
+// SetConcurrency sets the number of workers collecting tags from the parsed files and running the tag and struct processors.
+// By default the tags are collected by GOMAXPROCS workers and the processors run one at a time.
+// Above 1, the processors of different structs run concurrently, so processors sharing state must guard it.
+// Values lower than 1 reset it to the default.
+func (v *Validator) SetConcurrency(n int) {
+	if n < 1 {
+		v.concurrency = runtime.GOMAXPROCS(0)
+		v.processorConcurrency = 1
+
+		return
+	}
+
+	v.concurrency = n
+	v.processorConcurrency = n
+}
+
+// SetTimeout limits the duration of a run, on top of the context given to ValidateContext.
+// Once it expires no other file is parsed and no other processor is started, a processor already running is waited for.
+// The run then returns an error wrapping ErrTimeout along with the findings so far and Stats.Partial set.
+// Zero means no timeout.
+func (v *Validator) SetTimeout(d time.Duration) {
+	v.timeout = d
+}
+
+// SetRetainAST sets a flag if the parsed packages are kept after the tags were collected.
+// By default every file's AST is released as soon as its tags are collected.
+func (v *Validator) SetRetainAST(retainAST bool) {
+	v.retainAST = retainAST
+}
+
+// SetKnownTags enables reporting tag keys which are not known, e.g. a misspelled `josn:"name"`.
+// Every key of a tag is checked, the tags processors were added for are known as well.
+func (v *Validator) SetKnownTags(tags ...string) {
+	v.knownTags = map[string]bool{}
+
+	for _, tag := range tags {
+		v.knownTags[tag] = true
+	}
+}
+
+// AllowUnknownTags adds tag keys which are never reported as unknown.
+func (v *Validator) AllowUnknownTags(tags ...string) {
+	if v.allowedTags == nil {
+		v.allowedTags = map[string]bool{}
+	}
+
+	for _, tag := range tags {
+		v.allowedTags[tag] = true
+	}
+}
+
+// ExcludeFields leaves out the fields whose name matches one of the glob patterns, e.g. `XXX_*`, regardless of their tags.
+// Excluded fields are dropped while the tags are collected, so no check sees them.
+func (v *Validator) ExcludeFields(patterns ...string) {
+	v.excludeFields = append(v.excludeFields, patterns...)
+}
+
+// SetSkipUnexported leaves out the given tags of unexported fields, `*` is a reference to all tags.
+// Like excluded fields they are dropped while the tags are collected, so neither the processors nor the duplicates check see them,
+// e.g. for json tags, which encoding/json ignores on unexported fields.
+func (v *Validator) SetSkipUnexported(tags ...string) {
+	if v.skipUnexported == nil {
+		v.skipUnexported = map[string]bool{}
+	}
+
+	for _, tag := range tags {
+		v.skipUnexported[tag] = true
+	}
+}
+
+// RequireTag reports the fields which don't have all of the given tag keys, including fields without any tag.
+func (v *Validator) RequireTag(tags ...string) {
+	v.requiredTags = append(v.requiredTags, tags...)
+}
+
+// SetIncludeLocalStructs sets a flag if struct types declared in function bodies are validated as well.
+// They are named after the function, e.g. `Load.row` for a named type or `Load.local#1` for the first unnamed one.
+// By default they are skipped.
+func (v *Validator) SetIncludeLocalStructs(includeLocalStructs bool) {
+	v.localStructs = includeLocalStructs
+}
+
+// AllowEmptyValue lets the tags of the given names leave their name empty when options are given, e.g. `json:",omitempty"`.
+// A fully empty value, e.g. `json:""`, is still reported.
+func (v *Validator) AllowEmptyValue(tags ...string) {
+	if v.allowEmptyValue == nil {
+		v.allowEmptyValue = map[string]bool{}
+	}
+
+	for _, tag := range tags {
+		v.allowEmptyValue[tag] = true
+	}
+}
+
+// SetFailFastOnPanic sets a flag if a panicking processor crashes the run.
+// By default the panic is recovered and reported as a finding wrapping ErrProcessorPanic.
+func (v *Validator) SetFailFastOnPanic(failFastOnPanic bool) {
+	v.failFastOnPanic = failFastOnPanic
+}
+
+// SetReportUnusedTags sets a flag if the tags processors were added for, but which no struct has, are reported as warnings.
+// It surfaces typos in the tag names given to AddProcessor, see SetErrorOnUnusedTag to fail the run on them.
+func (v *Validator) SetReportUnusedTags(reportUnusedTags bool) {
+	v.reportUnusedTags = reportUnusedTags
+}
+
+// SetRecursive sets a flag if the subdirectories of the models folder are validated as well.
+// Like with the go command, testdata, vendor and directories starting with . or _ are skipped.
+func (v *Validator) SetRecursive(recursive bool) {
+	v.recursive = recursive
+}
+
+// SetFollowSymlinks sets a flag if symlinked files and directories in the models folder are traversed.
+// A file reachable through several links is parsed once and links back to a walked directory are not followed.
+// By default symlinks are skipped.
+func (v *Validator) SetFollowSymlinks(followSymlinks bool) {
+	v.followSymlinks = followSymlinks
+}
+
+// SetLogger sets the logger diagnostics are written to at debug level, e.g. which files were parsed and how long it took.
+// Nothing is logged by default.
+func (v *Validator) SetLogger(logger *slog.Logger) {
+	if logger == nil {
+		logger = slog.New(discardHandler{})
+	}
+
+	v.logger = logger
+}
+
+// discardHandler is a slog.Handler which drops every record.
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
 // NewValidator creates a new validator model.
 // It requires a path to the models folder.
 func NewValidator(path string) Validator {
 	m := Validator{}
 	m.setPath(path)
-	m.processors = map[string][]func(tag *Tag) []error{}
+	m.processors = map[string][]tagProcessor{}
+	m.structProcessors = map[string][]structProcessor{}
+	m.charsets = map[string]*charset{}
 	m.allowDuplicates = false
+	m.skipDashTags = true
+	m.duplicateKeys = true
+	m.buildContext = build.Default
+	m.concurrency = runtime.GOMAXPROCS(0)
+	m.processorConcurrency = 1
+	m.logger = slog.New(discardHandler{})
+	m.mu = &sync.Mutex{}
 
 	return m
 }
 
+// ErrNoTags is returned when the models hold none of the tags to validate.
+var ErrNoTags = errors.New("No tags found")
+
+// RunResult holds the outcome of a run.
+type RunResult struct {
+	//Findings are the problems found in the tags, without the ones suppressed by the baseline
+	Findings []*ValidationError
+	//Warnings are problems which didn't stop the run, e.g. files that failed to parse and were left out
+	Warnings []error
+	Stats    Stats
+}
+
+// HasFindings reports whether any problem was found in the tags.
+func (r *RunResult) HasFindings() bool {
+	return len(r.Findings) > 0
+}
+
 // Run  will validate specified tags on all models, if none were passed.
 // It returns validation errors, if any produced by the processor.
+// It is kept for compatibility, use Validate to tell the findings apart from setup failures.
 func (v *Validator) Run(models ...string) []error {
-	v.packages = getPackages(v.path, models...)
+	return runErrors(v.Validate(models...))
+}
+
+// runErrors puts the warnings, findings and error of a run in one slice.
+func runErrors(result *RunResult, err error) []error {
+	errs := append([]error{}, result.Warnings...)
+
+	if err != nil {
+		return append(errs, err)
+	}
+
+	for _, finding := range result.Findings {
+		errs = append(errs, finding)
+	}
+
+	return errs
+}
+
+// Validate will validate specified tags on all models, if none were passed.
+//
+//	result, err := m.Validate()
+//	if err != nil {
+//		// the models couldn't be validated, e.g. ErrNoTags
+//	}
+//	if result.HasFindings() {
+//		// result.Findings holds the problems found in the tags
+//	}
+//
+// The error reports a problem that prevented the validation.
+// The result is never nil, it holds the warnings and stats gathered up to that point.
+//
+// The models are the names of the files without their extension, e.g. invoice, one qualified with a package, e.g. billing.invoice,
+// only matches the file of the packages whose directory ends with it. Once more than one package is parsed the struct names are qualified
+// with the shortest trailing part of their package directory which tells it apart, e.g. billing.Invoice or v1/models.Invoice
+// next to v2/models.Invoice, see Tag.GetPackagePath.
+func (v *Validator) Validate(models ...string) (*RunResult, error) {
+	return v.ValidateContext(context.Background(), models...)
+}
+
+// ValidateContext works like Validate, but stops parsing the models once the context is done and returns its error.
+// A validator can run concurrently, every run works on a snapshot of its processors and options taken at the start.
+// Tags, Stats and the baseline methods report the run that finished last.
+func (v *Validator) ValidateContext(ctx context.Context, models ...string) (*RunResult, error) {
+	r := v.snapshot()
+	defer v.publish(r)
+
+	return r.validate(ctx, func(ctx context.Context, tags []string) (collection, error) {
+		return r.collect(ctx, tags, models...)
+	})
+}
+
+// validate collects the tags and runs the processors on them, the validator is a snapshot owned by the run.
+// Collecting and processing stop once the context is done or the run times out.
+func (v *Validator) validate(ctx context.Context, collect func(ctx context.Context, tags []string) (collection, error)) (result *RunResult, err error) {
+	start := time.Now()
+	v.stats = newStats()
+	v.findings = nil
+	v.files = nil
+	v.staleBaseline = nil
+	v.summary = nil
+	v.coverage = nil
+	result = &RunResult{
+		Findings: []*ValidationError{},
+		Warnings: []error{},
+	}
+
+	runCtx := ctx
+
+	if v.timeout > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, v.timeout)
+		defer cancel()
+	}
+
+	defer func() {
+		//Whichever step noticed the timeout, it is reported once
+		if err != nil && ctx.Err() == nil && errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+			err = fmt.Errorf("%w after %v: %w", ErrTimeout, v.timeout, runCtx.Err())
+			v.stats.Partial = true
+		}
 
-	if len(v.processors) == 0 {
-		return []error{
-			errors.New("there are no processors to run, consider adding the default ones"),
+		v.stats.ErrorsProduced = len(result.Findings) + len(result.Warnings)
+
+		if err != nil {
+			v.stats.ErrorsProduced++
 		}
+
+		v.stats.Duration = time.Since(start)
+		result.Stats = v.Stats()
+	}()
+
+	if len(v.processors) == 0 && len(v.structProcessors) == 0 && len(v.groupProcessors) == 0 && len(v.packageProcessors) == 0 && !v.tagSyntax {
+		return result, errors.New("there are no processors to run, consider adding the default ones")
+	}
+
+	tags := v.processorTags()
+
+	if len(tags) == 0 {
+		tags = []string{AllTags}
+	}
+
+	c, err := collect(runCtx, tags)
+
+	if err != nil {
+		return result, err
+	}
+
+	result.Warnings = append(append(result.Warnings, c.errs...), c.warnings...)
+	v.coverage = v.tagCoverage(tags)
+
+	if len(v.tags) == 0 {
+		return result, ErrNoTags
+	}
+
+	if err := v.checkGroups(); err != nil {
+		return result, err
+	}
+
+	validateStart := time.Now()
+	c.findings = append(c.findings, v.checkDeclarations()...)
+	c.findings = append(c.findings, v.process(runCtx)...)
+
+	if severity := v.unusedTagsSeverity(); len(severity) > 0 {
+		c.findings = append(c.findings, v.unusedTagFindings(severity)...)
+	}
+
+	result.Findings = v.suppressBaseline(c.findings)
+	v.applySeverity(result.Findings)
+	addEdits(v.fsys, result.Findings)
+	v.summary = countByProcessor(result.Findings)
+	v.stats.ValidateDuration = time.Since(validateStart)
+	v.logger.Debug("validated tags", "findings", len(result.Findings), "duration", v.stats.ValidateDuration)
+
+	return result, runCtx.Err()
+}
+
+// ListTags parses the models and returns the collected tags grouped by struct, in source order.
+// It lists the tags processors were added for, or all tags if there are none, without running any processor.
+func (v *Validator) ListTags(models ...string) (map[string][]*Tag, error) {
+	r := v.snapshot()
+	defer v.publish(r)
+
+	return r.listTags(models...)
+}
+
+// listTags collects the tags of the models, the validator is a snapshot owned by the call.
+func (v *Validator) listTags(models ...string) (map[string][]*Tag, error) {
+	v.stats = newStats()
+	tags := v.processorTags()
+
+	if len(tags) == 0 {
+		tags = []string{AllTags}
+	}
+
+	c, err := v.collect(context.Background(), tags, models...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return v.Tags(), errors.Join(append(c.errs, c.warnings...)...)
+}
+
+// snapshot returns a copy of the validator for a run to work on, so concurrent runs don't share their state.
+// The processors and the options changed in place are copied, the ones added or changed during a run don't take part in it.
+func (v *Validator) snapshot() *Validator {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	r := *v
+	r.charsets = make(map[string]*charset, len(v.charsets))
+
+	for tag, c := range v.charsets {
+		r.charsets[tag] = c
+	}
+
+	r.allowEmptyValue = copySet(v.allowEmptyValue)
+	r.allowDuplicateValues = copySet(v.allowDuplicateValues)
+	r.knownTags = copySet(v.knownTags)
+	r.allowedTags = copySet(v.allowedTags)
+	r.skipUnexported = copySet(v.skipUnexported)
+	r.processors = make(map[string][]tagProcessor, len(v.processors))
+
+	for tag, processors := range v.processors {
+		r.processors[tag] = append([]tagProcessor{}, processors...)
 	}
 
+	r.structProcessors = make(map[string][]structProcessor, len(v.structProcessors))
+
+	for tag, processors := range v.structProcessors {
+		r.structProcessors[tag] = append([]structProcessor{}, processors...)
+	}
+
+	r.groupProcessors = append([]groupProcessor{}, v.groupProcessors...)
+	r.packageProcessors = append([]packageProcessor{}, v.packageProcessors...)
+
+	return &r
+}
+
+// copySet copies a set of names, nil stays nil since it means the option isn't set.
+func copySet(set map[string]bool) map[string]bool {
+	if set == nil {
+		return nil
+	}
+
+	copied := make(map[string]bool, len(set))
+
+	for name := range set {
+		copied[name] = true
+	}
+
+	return copied
+}
+
+// publish makes the state of a finished run the state of the validator.
+func (v *Validator) publish(r *Validator) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.setRunState(r)
+}
+
+// setRunState copies the state of a run from r, the caller holds the lock.
+func (v *Validator) setRunState(r *Validator) {
+	v.packages = r.packages
+	v.fset = r.fset
+	v.tags = r.tags
+	v.stats = r.stats
+	v.findings = r.findings
+	v.files = r.files
+	v.staleBaseline = r.staleBaseline
+	v.summary = r.summary
+	v.coverage = r.coverage
+	v.keysCache = r.keysCache
+	v.keysCacheID = r.keysCacheID
+	v.keysCached = r.keysCached
+}
+
+// processorTags returns the tags processors were added for.
+func (v *Validator) processorTags() []string {
 	tags := []string{}
 
 	for tag := range v.processors {
 		tags = append(tags, tag)
 	}
 
-	v.tags = getTags(tags, v.packages)
+	for tag := range v.structProcessors {
+		if _, exists := v.processors[tag]; !exists {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+// Tags returns a copy of the tags collected by the last Run or ListTags, keyed by struct name.
+// The tags of a struct are in field declaration order.
+// It is empty before the first run.
+func (v *Validator) Tags() map[string][]*Tag {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	tags := make(map[string][]*Tag, len(v.tags))
+
+	for structName, structTags := range v.tags {
+		tags[structName] = append([]*Tag{}, structTags...)
+	}
+
+	return tags
+}
+
+// TagsFor returns a copy of the tags collected for the given struct by the last Run or ListTags.
+func (v *Validator) TagsFor(structName string) []*Tag {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	tags, exists := v.tags[structName]
+
+	if !exists {
+		return nil
+	}
+
+	return append([]*Tag{}, tags...)
+}
+
+// tagKeys returns the set of tag keys to collect for the given tag names.
+// The set is cached, so repeated runs with the same processors don't build it again.
+func (v *Validator) tagKeys(tags []string) map[string]bool {
+	sorted := append([]string{}, tags...)
+	sort.Strings(sorted)
+	id := strings.Join(sorted, "\x00")
+
+	if !v.keysCached || v.keysCacheID != id {
+		v.keysCache = tagKeys(sorted)
+		v.keysCacheID = id
+		v.keysCached = true
+	}
+
+	return v.keysCache
+}
+
+// collect parses the models and collects the given tags.
+// It returns an error if there was nothing to parse or the context is done.
+func (v *Validator) collect(ctx context.Context, tags []string, models ...string) (collection, error) {
+	skipped := []FileDiag{}
+	path, fileNames, err := getFiles(append([]string{v.path}, v.extraPaths...), fileWalker{
+		ctx:            v.buildContext,
+		logger:         v.logger,
+		recursive:      v.recursive,
+		followSymlinks: v.followSymlinks,
+		fsys:           v.fsys,
+		skipped:        &skipped,
+	}, models...)
+
+	if err != nil {
+		return collection{}, err
+	}
+
+	v.files = skipped
+
+	if len(fileNames) == 0 {
+		return collection{}, fmt.Errorf("No structs found at %v", path)
+	}
+
+	//Structs of one name in different packages are different structs, so their names are qualified
+	v.qualifiers = packageQualifiers(fileNames)
+	v.fset = token.NewFileSet()
+	col := v.newCollector(tags)
+	start := time.Now()
+	c, err := getTags(ctx, col, fileNames, v.concurrency, v.retainAST)
+
+	if err != nil {
+		return collection{}, err
+	}
+
+	v.logger.Debug("parsed files", "files", len(fileNames), "failed", len(c.errs), "structs", len(c.tags), "duration", time.Since(start))
+	v.files = append(v.files, c.files...)
+	sortFileDiags(v.files)
+
+	//A broken file doesn't stop the others from being validated, unless none is left
+	if len(c.errs) == len(fileNames) {
+		return collection{}, fmt.Errorf("None of the %v files at %v could be parsed: %w", len(fileNames), path, errors.Join(c.errs...))
+	}
+
+	v.stats.FilesParsed = len(fileNames) - len(c.errs)
+	v.stats.FilesCached = c.filesCached
+	v.stats.StructsFound = len(c.tags)
+	v.stats.FieldsExcluded = c.fieldsExcluded
+
+	if col.cache != nil {
+		col.cache.save(col)
+	}
+
+	c.warnings = v.filterStructs(&c)
+	v.packages = c.packages
+	v.tags = c.tags
+
+	return c, nil
+}
+
+// newCollector creates a collector of the given tags with the settings of the validator.
+func (v *Validator) newCollector(tags []string) *collector {
+	col := &collector{
+		fset:        v.fset,
+		keys:        v.tagKeys(tags),
+		allowedTags: v.allowedTags,
+
+		checkDuplicateKeys: v.duplicateKeys,
+		checkSyntax:        v.tagSyntax,
+		excludeFields:      v.excludeFields,
+		skipUnexported:     v.skipUnexported,
+		requiredTags:       v.requiredTags,
+
+		includeLocalStructs: v.localStructs,
+		qualifiers:          v.qualifiers,
+		fsys:                v.fsys,
+		logger:              v.logger,
+		messages:            v.messages,
+	}
+
+	//The files of an fs.FS may have no modification times, e.g. the ones of an embed.FS
+	if v.fsys == nil {
+		col.cache = v.cache
+	}
+
+	//Tags processors were added for are known as well, like the required ones
+	if v.knownTags != nil {
+		col.knownTags = map[string]bool{}
 
-	return v.validate()
+		for name := range v.knownTags {
+			col.knownTags[name] = true
+		}
+
+		for _, name := range v.requiredTags {
+			col.knownTags[name] = true
+		}
+
+		for _, name := range v.processorTags() {
+			if name != AllTags {
+				col.knownTags[name] = true
+			}
+		}
+	}
+
+	if col.cache != nil {
+		col.cacheSettings = col.fingerprint()
+	}
+
+	return col
 }
 
-func (v *Validator) validate() []error {
-	fieldsCache := map[string]bool{}
+// process runs the processors on the collected tags.
+// The tag and struct processors of different struct declarations run on as many workers as SetConcurrency allows, one by default,
+// the findings keep the order of the structs by name and of their fields whatever the number of workers.
+// Once the context is done no other processor is started, the findings so far are returned.
+func (v *Validator) process(ctx context.Context) []error {
+	structNames := make([]string, 0, len(v.tags))
+
+	for structName := range v.tags {
+		structNames = append(structNames, structName)
+	}
+
+	sort.Strings(structNames)
+
+	//Structs of one name declared in several files are validated separately, see checkDeclarations
+	declarations := [][]*Tag{}
+
+	for _, structName := range structNames {
+		declarations = append(declarations, declarationGroups(v.tags[structName])...)
+	}
+
 	errs := []error{}
 
-	if len(v.tags) == 0 {
-		return []error{errors.New("No tags found")}
+	for _, declErrs := range v.processDeclarations(ctx, declarations) {
+		errs = append(errs, declErrs...)
 	}
 
-	for _, fields := range v.tags {
-		for _, t := range fields {
-			executableProcessors := []func(tag *Tag) []error{}
+	if ctx.Err() != nil {
+		return errs
+	}
+
+	errs = append(errs, v.processGroups()...)
+
+	if ctx.Err() != nil {
+		return errs
+	}
+
+	return append(errs, v.processPackage()...)
+}
+
+// processCounts are the stats counted by one worker, they are added to the stats of the run once the workers are done.
+type processCounts struct {
+	tagsCollected map[string]int
+	processorsRun int
+	//processorsByTag counts the processors run per tag key, see Coverage
+	processorsByTag map[string]int
+}
+
+// processDeclarations runs the tag and struct processors on every struct declaration, it returns the findings of each.
+// Duplicates are scoped by the declaration, so every declaration checks them with a cache of its own and no locking.
+// A processor panicking while failing fast, see SetFailFastOnPanic, panics again once the workers are done.
+func (v *Validator) processDeclarations(ctx context.Context, declarations [][]*Tag) [][]error {
+	results := make([][]error, len(declarations))
+	workers := max(min(v.processorConcurrency, len(declarations)), 1)
+	counts := make([]processCounts, workers)
+
+	//Cancelled on a panic, so the other workers stop early
+	workCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var panicked *interface{}
+
+	queue := make(chan int)
+	wg.Add(workers)
+
+	for w := 0; w < workers; w++ {
+		counts[w].tagsCollected = map[string]int{}
+		counts[w].processorsByTag = map[string]int{}
+
+		go func(c *processCounts) {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					mu.Lock()
+
+					if panicked == nil {
+						panicked = &r
+					}
+
+					mu.Unlock()
+					cancel()
+				}
+			}()
+
+			for i := range queue {
+				results[i] = v.processFields(workCtx, declarations[i], map[string]*Tag{}, c)
 
-			if !v.allowDuplicates {
-				errs = append(errs, checkForDuplicates(t, fieldsCache)...)
+				if workCtx.Err() != nil {
+					continue
+				}
+
+				results[i] = append(results[i], v.processStruct(declarations[i], c)...)
 			}
+		}(&counts[w])
+	}
+
+enqueue:
+	for i := range declarations {
+		select {
+		case queue <- i:
+		case <-workCtx.Done():
+			break enqueue
+		}
+	}
+
+	close(queue)
+	wg.Wait()
+
+	if panicked != nil {
+		panic(*panicked)
+	}
+
+	for _, c := range counts {
+		for name, count := range c.tagsCollected {
+			v.stats.TagsCollected[name] += count
+		}
+
+		v.stats.ProcessorsRun += c.processorsRun
+	}
+
+	v.countProcessorsRun(counts)
+
+	return results
+}
+
+// processFields runs the tag processors and the duplicates check on the tags of one struct declaration.
+func (v *Validator) processFields(ctx context.Context, fields []*Tag, fieldsCache map[string]*Tag, counts *processCounts) []error {
+	errs := []error{}
+
+	for _, t := range fields {
+		if ctx.Err() != nil {
+			return errs
+		}
 
-			processors, exists := v.processors[t.GetName()]
+		counts.tagsCollected[t.GetName()]++
+		executableProcessors := []tagProcessor{}
 
-			if exists {
-				executableProcessors = append(processors)
+		if !v.allowDuplicates && !v.allowDuplicateValues[t.GetName()] && !v.isSkipped(t) {
+			errs = append(errs, attributeErrors(wrapErrors(t, checkForDuplicates(t, t.declarationKey(), fieldsCache, v.messages)), DuplicatesProcessor)...)
+		}
+
+		processors, exists := v.processors[t.GetName()]
+
+		if exists {
+			executableProcessors = append(executableProcessors, processors...)
+		}
+
+		globalProcessors, exists := v.processors[AllTags]
+
+		if exists {
+			executableProcessors = append(executableProcessors, globalProcessors...)
+		}
+
+		//Once a processor stops, the ones of a lower priority are skipped
+		stoppedAt := 0
+		stop := false
+
+		for _, processor := range byPriority(executableProcessors) {
+			if ctx.Err() != nil {
+				return errs
+			}
+
+			if stop && processor.priority < stoppedAt {
+				break
 			}
 
-			globalProcessors, exists := v.processors[AllTags]
+			processorErrs, stops := stopped(v.runProcessor(t, processor))
 
-			if exists {
-				executableProcessors = append(executableProcessors, globalProcessors...)
+			if stops && !stop {
+				stop = true
+				stoppedAt = processor.priority
 			}
 
-			for _, processor := range executableProcessors {
-				errs = append(errs, processor(t)...)
+			errs = append(errs, attributeErrors(wrapErrors(t, processorErrs), processor.name)...)
+			counts.processorsRun++
+			counts.processorsByTag[t.GetName()]++
+		}
+	}
+
+	return errs
+}
+
+// processStruct runs the struct processors on the tags of one struct.
+func (v *Validator) processStruct(fields []*Tag, counts *processCounts) []error {
+	errs := []error{}
+	tags := make([]string, 0, len(v.structProcessors))
+
+	for tag := range v.structProcessors {
+		tags = append(tags, tag)
+	}
+
+	//The processors run in the order of their tags, so the findings of a struct keep their order every run
+	sort.Strings(tags)
+
+	for _, tag := range tags {
+		processors := v.structProcessors[tag]
+		s := &StructInfo{
+			Name:       fields[0].GetStructName(),
+			Tags:       make([]*Tag, 0, len(fields)),
+			Doc:        fields[0].StructDoc(),
+			Directives: map[string]string{},
+		}
+
+		if fields[0].declaration != nil {
+			for key, value := range fields[0].declaration.directives {
+				s.Directives[key] = value
+			}
+		}
+
+		for _, t := range fields {
+			if tag == AllTags || t.GetName() == tag {
+				s.Tags = append(s.Tags, t)
+			}
+		}
+
+		if len(s.Tags) == 0 {
+			continue
+		}
+
+		//Errors are reported at the struct's first tag unless the processor points at a field
+		at := &Tag{structName: &s.Name, pos: s.Tags[0].GetPosition()}
+
+		if tag != AllTags {
+			name := tag
+			at.name = &name
+		}
+
+		for _, processor := range processors {
+			errs = append(errs, attributeErrors(wrapErrors(at, v.runStructProcessor(at, s, processor.run)), processor.name)...)
+		}
+
+		counts.processorsRun += len(processors)
+
+		//A processor of all tags covers every key the struct has
+		covered := map[string]bool{}
+
+		for _, t := range s.Tags {
+			if !covered[t.GetName()] {
+				covered[t.GetName()] = true
+				counts.processorsByTag[t.GetName()] += len(processors)
 			}
 		}
 	}
@@ -157,8 +1080,68 @@ func (v *Validator) validate() []error {
 	return errs
 }
 
+// runProcessor runs a processor on the tag, a panic is returned as an error wrapping ErrProcessorPanic.
+// The validator is the snapshot of the run, the processors reading options are given it.
+func (v *Validator) runProcessor(t *Tag, processor tagProcessor) (errs []error) {
+	defer v.recoverPanic(t, &errs)
+
+	if processor.runWith != nil {
+		return processor.runWith(v, t)
+	}
+
+	return processor.run(t)
+}
+
+// runStructProcessor runs a struct processor, a panic is returned as an error reported at the given tag.
+func (v *Validator) runStructProcessor(at *Tag, s *StructInfo, processor func(s *StructInfo) []error) (errs []error) {
+	defer v.recoverPanic(at, &errs)
+
+	return processor(s)
+}
+
+// recoverPanic replaces the errors of a processor with the panic it recovered from, unless failing fast.
+func (v *Validator) recoverPanic(t *Tag, errs *[]error) {
+	if v.failFastOnPanic {
+		return
+	}
+
+	if r := recover(); r != nil {
+		*errs = []error{newPanicError(t, r)}
+	}
+}
+
+// StructInfo describes a struct for the struct processors.
+type StructInfo struct {
+	Name string
+	//Tags are the tags the processor was added for, in field declaration order
+	Tags []*Tag
+	//Doc is the doc comment of the struct and Directives are the key=value pairs of its directives, see DirectivePrefix
+	Doc        string
+	Directives map[string]string
+}
+
+// AddStructProcessor adds a processor that validates the tags of a struct together, e.g. that the id comes first.
+// It is called once per struct holding the given tag, `*` is a reference to all tags.
+// Its findings are counted under the name of its function in Summary and it may be called concurrently once SetConcurrency is set above 1.
+func (v *Validator) AddStructProcessor(tag string, processor func(s *StructInfo) []error) {
+	v.AddStructProcessorNamed(tag, v.processorLabel(processor), processor)
+}
+
+// AddStructProcessorNamed works like AddStructProcessor, its findings are counted under the given name in Summary.
+func (v *Validator) AddStructProcessorNamed(tag, name string, processor func(s *StructInfo) []error) {
+	v.structProcessors[tag] = append(v.structProcessors[tag], structProcessor{name, processor})
+}
+
 // AddProcessor adds a processor that will validate the given model tags
 // The tags given for the processors will be the tags parsed by the validator where `*` is a reference to all tags
+// Its findings are counted under the name of its function in Summary, e.g. `checkColumn`,
+// function literals are numbered in the order they were added, e.g. `processor #2`.
+// It may be called concurrently for the tags of different structs once SetConcurrency is set above 1.
 func (v *Validator) AddProcessor(tag string, processor func(t *Tag) []error) {
-	v.processors[tag] = append(v.processors[tag], processor)
+	v.AddProcessorNamed(tag, v.processorLabel(processor), processor)
+}
+
+// AddProcessorNamed works like AddProcessor, its findings are counted under the given name in Summary.
+func (v *Validator) AddProcessorNamed(tag, name string, processor func(t *Tag) []error) {
+	v.addProcessor(tag, name, PriorityDefault, processor)
 }
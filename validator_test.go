@@ -1,14 +1,21 @@
 package validator
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"github.com/stretchr/testify/require"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"reflect"
+	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 const cnt = 0xC350 //50k
@@ -67,8 +74,14 @@ func createModel(fileName string, structs []structTpl) {
 		}, "")
 	}
 
+	createFile(fileName, tmp)
+}
+
+func createFile(fileName string, content string) {
+	os.Mkdir("./models", 0755)
+
 	f, _ := os.Create(filepath.Join("models", fileName))
-	f.WriteString(tmp)
+	f.WriteString(content)
 	f.Close()
 }
 
@@ -167,6 +180,7 @@ func Test_testValidateDuplicates(t *testing.T) {
 	defer os.RemoveAll("./models")
 
 	m := NewValidator(modelsPath)
+	m.SetRetainAST(true)
 	m.AddDefaultProcessors("db")
 
 	errs := m.Run("Customer")
@@ -243,63 +257,850 @@ func Test_testValidator_ErrorsCount(t *testing.T) {
 	os.RemoveAll("./models")
 }
 
-func BenchmarkModel_ValidateNoErrors(b *testing.B) {
+func Test_testValidateRetainAST(t *testing.T) {
+	r := require.New(t)
 
-	//We don't want to add the struct creation time into the benchmark
-	//so we stop the timer
-	b.StopTimer()
+	structs := []structTpl{
+		{
+			"Customer",
+			"created_at",
+			"updated_at",
+			"",
+		},
+	}
 
-	//Let's stress the program and create 50k models
-	for i := 0; i < cnt; i++ {
-		structs := []structTpl{{
-			"Customer" + strconv.Itoa(i),
-			"created_at" + strconv.Itoa(i),
-			"updated_at" + strconv.Itoa(i),
-			"updated_at" + strconv.Itoa(i),
+	createModel("customer.go", structs)
+	createModel("customer1.go", []structTpl{{"Customer1", "created_at", "updated_at", ""}})
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("db")
+
+	r.Empty(m.Run())
+	r.Nil(m.packages)
+	r.Len(m.tags, 2)
+
+	m.SetRetainAST(true)
+
+	r.Empty(m.Run())
+	r.Len(m.packages, 1)
+	r.Len(m.packages["models"].Files, 2)
+	r.Len(m.tags, 2)
+}
+
+func Test_testValidateStats(t *testing.T) {
+	r := require.New(t)
+
+	structs := []structTpl{
+		{
+			"Customer",
+			"created_at",
+			"created_at",
+			"",
 		},
-		}
+		{
+			"Customer1",
+			"created_at",
+			"updated_at",
+			"",
+		},
+	}
 
-		createModel("Customer"+strconv.Itoa(i)+".go", structs)
+	createModel("customer.go", structs)
+	createModel("customer1.go", []structTpl{{"Customer2", "created_at", "updated_at", ""}})
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("db")
+	m.AddProcessor("json", func(tag *Tag) []error {
+		return nil
+	})
+
+	for i := 0; i < 2; i++ {
+		errs := m.Run()
+		stats := m.Stats()
+
+		r.Len(errs, 1)
+		r.Equal(2, stats.FilesParsed)
+		r.Equal(3, stats.StructsFound)
+		r.Equal(map[string]int{"db": 9, "json": 9}, stats.TagsCollected)
+		r.Equal(9*2+9, stats.ProcessorsRun)
+		r.Equal(1, stats.ErrorsProduced)
+		r.NotZero(stats.Duration)
 	}
 
-	b.StartTimer()
+	stats := m.Stats()
+	stats.TagsCollected["db"] = 0
 
-	for i := 0; i < 5; i++ {
-		//Lets time the meat and potatoes of the benchmark
-		b.Run("subtest", func(b *testing.B) {
-			for i := 0; i < b.N; i++ {
-				m := NewValidator(modelsPath)
-				m.AddDefaultProcessors("db")
-				m.Run()
-			}
-		})
+	r.Equal(9, m.Stats().TagsCollected["db"])
+}
+
+func Test_testListTags(t *testing.T) {
+	r := require.New(t)
+
+	structs := []structTpl{
+		{
+			"Customer",
+			"created-at",
+			"created-at",
+			"",
+		},
 	}
 
-	//Don't want to time the deletion of the files
-	b.StopTimer()
-	os.RemoveAll("./models")
+	createModel("customer.go", structs)
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	tags, err := m.ListTags()
+
+	r.NoError(err)
+	r.Len(tags, 1)
+
+	values := []string{}
+
+	for _, tag := range tags["Customer"] {
+		values = append(values, tag.GetName()+":"+tag.GetValue())
+	}
+
+	r.Equal([]string{
+		"json:id", "db:id",
+		"json:created_at", "db:created-at",
+		"json:updated_at", "db:created-at",
+	}, values)
+
+	m.AddProcessor("db", func(tag *Tag) []error {
+		return []error{errors.New("not run")}
+	})
+
+	tags, err = m.ListTags()
+
+	r.NoError(err)
+	r.Len(tags["Customer"], 3)
+	r.Equal(0, m.Stats().ProcessorsRun)
 }
 
-func BenchmarkModel_ValidateWithErrors(b *testing.B) {
+func Test_testValidateSuggestions(t *testing.T) {
+	r := require.New(t)
 
-	//We don't want to add the struct creation time into the benchmark
-	//so we stop the timer
-	b.StopTimer()
+	structs := []structTpl{
+		{
+			"Customer",
+			"created-At",
+			"created-At",
+			"",
+		},
+		{
+			"Customer1",
+			"created_at",
+			"updated_at__",
+			"",
+		},
+	}
 
-	//Let's stress the program and create 50k models
-	for i := 0; i < cnt; i++ {
-		structs := []structTpl{{
-			"Customer" + strconv.Itoa(i),
-			"updated_at" + strconv.Itoa(i),
-			"updated_at" + strconv.Itoa(i),
-			"updated_at" + strconv.Itoa(i),
+	createModel("customer.go", structs)
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("db")
+	m.AddProcessor("json", func(tag *Tag) []error {
+		if tag.GetValue() != "id" {
+			return nil
+		}
+
+		return []error{NewErrorWithSuggestion(errors.New("Reserved value"), "customer_id")}
+	})
+
+	messages := []string{}
+
+	for _, err := range m.Run() {
+		messages = append(messages, err.Error())
+	}
+
+	r.ElementsMatch([]string{
+		"Invalid symboles -A in Customer.db.created-At, charset [a-z0-9_, ] (suggested: created_at)",
+		"Invalid symboles -A in Customer.db.created-At, charset [a-z0-9_, ] (suggested: created_at)",
+		"Duplicate tag value created-At in Customer.db (suggested: rename it, the value is held by Customer.CreatedAt)",
+		"Tag cannot end on _ in  Customer1.db.updated_at__, charset [a-z0-9_, ] (suggested: updated_at)",
+		"Reserved value (suggested: customer_id)",
+		"Reserved value (suggested: customer_id)",
+	}, messages)
+}
+
+func Test_testValidateUnknownTags(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", `package models
+
+type Customer struct {
+	ID    int    `+"`"+`json:"id" db:"id" internal:"pk"`+"`"+`
+	Name  string `+"`"+`josn:"name" db:"name"`+"`"+`
+	Email string `+"`"+`jsn:"email" dbb:"email" yaml:"email"`+"`"+`
+}
+`)
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("db")
+
+	r.Empty(m.Run())
+
+	m.SetKnownTags("json")
+	m.AllowUnknownTags("internal")
+
+	messages := []string{}
+
+	for _, err := range m.Run() {
+		messages = append(messages, err.Error())
+	}
+
+	r.ElementsMatch([]string{
+		"Unknown tag josn in Customer.Name (suggested: json)",
+		"Unknown tag jsn in Customer.Email (suggested: json)",
+		"Unknown tag dbb in Customer.Email (suggested: db)",
+		"Unknown tag yaml in Customer.Email",
+	}, messages)
+}
+
+func Test_testTags(t *testing.T) {
+	r := require.New(t)
+
+	structs := []structTpl{
+		{
+			"Customer",
+			"created_at",
+			"updated_at",
+			"",
+		},
+		{
+			"Customer1",
+			"created_at",
+			"updated_at",
+			"",
 		},
+	}
+
+	createModel("customer.go", structs)
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("db")
+
+	r.Empty(m.Tags())
+	r.Nil(m.TagsFor("Customer"))
+	r.Empty(m.Run())
+
+	tags := m.Tags()
+
+	r.Len(tags, 2)
+	r.Len(tags["Customer"], 3)
+	r.Equal(tags["Customer"], m.TagsFor("Customer"))
+	r.Nil(m.TagsFor("Missing"))
+
+	delete(tags, "Customer1")
+	tags["Customer"][0] = nil
+	tags["Customer"] = tags["Customer"][:1]
+
+	customerTags := m.TagsFor("Customer")
+	customerTags[1] = nil
+
+	r.Len(m.Tags(), 2)
+	r.Len(m.TagsFor("Customer"), 3)
+
+	for _, tag := range m.TagsFor("Customer") {
+		r.NotNil(tag)
+	}
+}
+
+func Test_testTagValuePos(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", "package models\n\n"+
+		"type Customer struct {\n"+
+		"\tID   int    `json:\"id\" db:\"customer_id\"`\n"+
+		"\tName string \"json:\\\"full\\\\tname\\\" db:\\\"name\\\"\"\n"+
+		"\tNote string `json:\"note\"\r\r db:\"note\"`\n"+
+		"\tMemo string `json:\"memo\"\n\tdb:\"memo\"`\n"+
+		"}\n")
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	_, err := m.ListTags()
+	r.NoError(err)
+
+	positions := map[string][2]string{}
+
+	for _, tag := range m.TagsFor("Customer") {
+		start, end := tag.GetValuePos()
+		positions[tag.GetFieldName()+"."+tag.GetName()] = [2]string{
+			fmt.Sprintf("%v:%v", start.Line, start.Column),
+			fmt.Sprintf("%v:%v", end.Line, end.Column),
 		}
+	}
 
-		createModel("Customer"+strconv.Itoa(i)+".go", structs)
+	r.Equal(map[string][2]string{
+		"ID.json":   {"4:21", "4:23"},
+		"ID.db":     {"4:29", "4:40"},
+		"Name.json": {"5:22", "5:33"},
+		"Name.db":   {"5:41", "5:45"},
+		"Note.json": {"6:21", "6:25"},
+		"Note.db":   {"6:33", "6:37"},
+		//Like reflect, pairs are separated by spaces only, the rest of a tag spanning lines is left out
+		"Memo.json": {"7:21", "7:25"},
+	}, positions)
+}
+
+func Test_testTagsExactKeys(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", "package models\n\ntype Customer struct {\n"+
+		"ID string `mydb:\"Bad-Value\" db:\"id\"`\n"+
+		"Note string `json:\"note db:\\\"Bad-Value\\\"\" db:\"note\"`\n"+
+		"}\n")
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("db")
+
+	r.Empty(m.Run())
+
+	tags := m.TagsFor("Customer")
+
+	r.Len(tags, 2)
+	r.Equal("id", tags[0].GetValue())
+	r.Equal("note", tags[1].GetValue())
+
+	//All keys are collected for AllTags, each exactly once
+	m = NewValidator(modelsPath)
+
+	all, err := m.ListTags()
+
+	r.NoError(err)
+	r.Len(all["Customer"], 4)
+	r.Equal("mydb", all["Customer"][0].GetName())
+	r.Equal(`note db:"Bad-Value"`, all["Customer"][2].GetValue())
+}
+
+func Test_testValidateStructFilters(t *testing.T) {
+	r := require.New(t)
+
+	createModel("customer.go", []structTpl{
+		{"Customer", "created_at", "created_at", ""},
+		{"AuditCustomer", "created_at", "created_at", ""},
+		{"CustomerModel", "created_at", "created_at", ""},
+	})
+	createModel("order.go", []structTpl{
+		{"OrderCustomer", "created_at", "created_at", ""},
+	})
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("db")
+	m.IncludeStructs("*Customer", "Typo*")
+	m.ExcludeStructs("Audit*")
+
+	//The models argument still selects the files
+	result, err := m.Validate("Customer")
+
+	r.NoError(err)
+	r.Len(result.Findings, 1)
+	r.Equal("Customer", result.Findings[0].Struct)
+	r.Len(result.Warnings, 1)
+	r.Equal("Struct pattern Typo* matched no struct", result.Warnings[0].Error())
+	r.Equal(3, result.Stats.StructsFound)
+	r.Equal(2, result.Stats.StructsSkipped)
+
+	result, err = m.Validate()
+
+	r.NoError(err)
+	r.Len(result.Findings, 2)
+	r.Equal(2, result.Stats.StructsSkipped)
+}
+
+func Test_testValidateExcludeFields(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", "package models\n\ntype Customer struct {\n"+
+		"ID string `db:\"id\"`\n"+
+		"Name string `db:\"name\"`\n"+
+		"Email string\n"+
+		"DeprecatedName string `db:\"name\"`\n"+
+		"XXX_unrecognized []byte `json:\"-\"`\n"+
+		"XXX_sizecache int32\n"+
+		"}\n")
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("db")
+	m.RequireTag("db")
+
+	//Without exclusions the deprecated field holds a duplicate value and the protobuf fields miss the tag
+	r.Len(m.Run(), 4)
+
+	m.ExcludeFields("XXX_*", "Deprecated*")
+
+	result, err := m.Validate()
+
+	r.NoError(err)
+	r.Len(result.Findings, 1)
+	r.Equal("Missing tag db in Customer.Email", result.Findings[0].Error())
+	r.Equal(6, result.Findings[0].Pos.Line)
+	r.Equal(3, result.Stats.FieldsExcluded)
+	r.Len(m.TagsFor("Customer"), 2)
+}
+
+func Test_testValidateLocalStructs(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", "package models\n\ntype Customer struct {\n"+
+		"ID string `db:\"id\"`\n"+
+		"}\n\n"+
+		"func (c *Customer) Load() {\n"+
+		"\ttype row struct {\n"+
+		"\t\tID string `db:\"id\"`\n"+
+		"\t\tKey string `db:\"id\"`\n"+
+		"\t}\n"+
+		"\tvar rows []struct {\n"+
+		"\t\tName string `db:\"Name\"`\n"+
+		"\t}\n"+
+		"\t_ = rows\n"+
+		"}\n\n"+
+		"func count() int {\n"+
+		"\treturn len([]struct {\n"+
+		"\t\tTotal int `db:\"total\"`\n"+
+		"\t}{})\n"+
+		"}\n")
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("db")
+
+	r.Empty(m.Run())
+	r.Len(m.Tags(), 1)
+
+	m.SetIncludeLocalStructs(true)
+	errs := m.Run()
+
+	r.Len(errs, 2)
+	r.Len(m.Tags(), 4)
+	r.Len(m.TagsFor("count.local#1"), 1)
+
+	report := NewReport(errs)
+
+	r.Equal("Customer.Load.row", report.Findings[0].Struct)
+	r.Contains(report.Findings[0].Error(), "Duplicate tag value id")
+	r.Equal(10, report.Findings[0].Pos.Line)
+	r.Equal("Customer.Load.local#1", report.Findings[1].Struct)
+	r.Equal(13, report.Findings[1].Pos.Line)
+}
+
+func Test_testTagsSourceOrder(t *testing.T) {
+	r := require.New(t)
+
+	fields := []string{}
+	expected := []string{}
+
+	for i := 0; i < 30; i++ {
+		name := fmt.Sprintf("field_%02d", 29-i)
+		fields = append(fields, fmt.Sprintf("F%v string `db:\"%v\" json:\"%v\"`", i, name, name))
+		expected = append(expected, name, name)
 	}
 
-	b.StartTimer()
+	createFile("customer.go", "package models\n\ntype Customer struct {\n"+strings.Join(fields, "\n")+"\n}\n")
+
+	for i := 0; i < 10; i++ {
+		createModel(fmt.Sprintf("order%v.go", i), []structTpl{{fmt.Sprintf("Order%v", i), "created_at", "updated_at", ""}})
+	}
+
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.SetConcurrency(4)
+
+	for i := 0; i < 5; i++ {
+		tags, err := m.ListTags()
+
+		r.NoError(err)
+
+		values := []string{}
+
+		for _, tag := range tags["Customer"] {
+			values = append(values, tag.GetValue())
+		}
+
+		r.Equal(expected, values)
+	}
+}
+
+func Test_testValidateStructProcessor(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", "package models\n\ntype Customer struct {\n"+
+		"Name string `db:\"name\" json:\"name\"`\n"+
+		"ID string `db:\"id\" json:\"id\"`\n"+
+		"}\n\n"+
+		"type Order struct {\n"+
+		"ID string `db:\"id\"`\n"+
+		"}\n")
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddStructProcessor("db", func(s *StructInfo) []error {
+		if s.Tags[0].GetValue() != "id" {
+			return []error{fmt.Errorf("The id must be the first db tag of %v", s.Name)}
+		}
+
+		return nil
+	})
+
+	errs := m.Run()
+
+	r.Len(errs, 1)
+
+	var finding *ValidationError
+
+	r.True(errors.As(errs[0], &finding))
+	r.Equal("The id must be the first db tag of Customer", finding.Message)
+	r.Equal("Customer", finding.Struct)
+	r.Equal("db", finding.Tag)
+	r.Equal(4, finding.Pos.Line)
+	r.Equal(2, m.Stats().ProcessorsRun)
+}
+
+func Test_testValidateStructProcessorOrder(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", "package models\n\ntype Customer struct {\n"+
+		"ID string `db:\"id\" json:\"id\" xml:\"id\"`\n"+
+		"}\n")
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+
+	for _, tag := range []string{"xml", AllTags, "json", "db"} {
+		tag := tag
+
+		m.AddStructProcessor(tag, func(s *StructInfo) []error {
+			return []error{fmt.Errorf("Checked %v of %v", tag, s.Name)}
+		})
+	}
+
+	//The findings of a struct are in the order of the tags every run
+	for i := 0; i < 20; i++ {
+		result, err := m.Validate()
+
+		r.NoError(err)
+		r.Equal([]string{
+			"Checked * of Customer",
+			"Checked db of Customer",
+			"Checked json of Customer",
+			"Checked xml of Customer",
+		}, findingMessages(result.Findings))
+	}
+}
+
+func Test_testValidatePunctuatedKeys(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", "package models\n\ntype Customer struct {\n"+
+		"ID string `bun:\"id,pk\" db.index:\"idx_id\"`\n"+
+		"Name string `bun:\"name\" db.index:\"Idx-Name\"`\n"+
+		"}\n")
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("db.index")
+	m.AddProcessor("db.idnex", func(tag *Tag) []error { return nil })
+	m.SetReportUnusedTags(true)
+
+	result, err := m.Validate()
+
+	r.NoError(err)
+	r.Len(m.TagsFor("Customer"), 2)
+	r.Equal("db.index", m.TagsFor("Customer")[0].GetName())
+	r.Len(result.Findings, 2)
+	r.Contains(result.Findings[0].Error(), "Invalid symboles I in Customer.db.index.Idx-Name")
+	r.Equal("Tag db.idnex has processors, but no struct has it", result.Findings[1].Error())
+	r.Equal(SeverityWarning, result.Findings[1].Severity)
+
+	//All tags include the punctuated keys
+	m = NewValidator(modelsPath)
+	tags, err := m.ListTags()
+
+	r.NoError(err)
+	r.Len(tags["Customer"], 4)
+	r.Equal("db.index", tags["Customer"][1].GetName())
+}
+
+func Test_testValidateMultipleGOPATH(t *testing.T) {
+	r := require.New(t)
+
+	first, second := t.TempDir(), t.TempDir()
+	folder := filepath.Join(second, "src", "example.com", "models")
+
+	r.NoError(os.MkdirAll(folder, 0755))
+	r.NoError(os.WriteFile(filepath.Join(folder, "customer.go"), []byte("package models\n\ntype Customer struct {\n"+
+		"ID string `db:\"id\"`\n"+
+		"}\n"), 0644))
+
+	t.Setenv("GOPATH", strings.Join([]string{first, second}, string(filepath.ListSeparator)))
+
+	m := NewValidator("example.com/models")
+	m.AddDefaultProcessors("db")
+
+	r.Empty(m.Run())
+	r.Len(m.TagsFor("Customer"), 1)
+
+	m = NewValidator("example.com/missing")
+	m.AddDefaultProcessors("db")
+
+	_, err := m.Validate()
+
+	r.EqualError(err, fmt.Sprintf("Models folder example.com/missing not found, looked in %v, %v",
+		filepath.Join(first, "src", "example.com", "missing"),
+		filepath.Join(second, "src", "example.com", "missing")))
+
+	//The default GOPATH of the go command is used when it is unset
+	t.Setenv("GOPATH", "")
+	t.Setenv("HOME", first)
+
+	_, err = m.Validate()
+
+	r.EqualError(err, fmt.Sprintf("Models folder example.com/missing not found, looked in %v",
+		filepath.Join(first, "go", "src", "example.com", "missing")))
+}
+
+func Test_testValidateFilesystemPaths(t *testing.T) {
+	r := require.New(t)
+
+	createModel("customer.go", []structTpl{{"Customer", "created_at", "created_at", ""}})
+	defer os.RemoveAll("./models")
+
+	abs, err := filepath.Abs("models")
+	r.NoError(err)
+
+	for _, path := range []string{"./models", "models", abs} {
+		m := NewValidator(path)
+		m.AddDefaultProcessors("db")
+
+		errs := m.Run()
+
+		r.Len(errs, 1, path)
+		r.Equal(filepath.Join(abs, "customer.go"), NewReport(errs).Findings[0].Pos.Filename)
+	}
+
+	missing := filepath.Join(abs, "missing")
+	m := NewValidator(missing)
+	m.AddDefaultProcessors("db")
+
+	_, err = m.Validate()
+
+	r.EqualError(err, fmt.Sprintf("Models folder %v not found", missing))
+}
+
+func Test_testValidateSymlinks(t *testing.T) {
+	r := require.New(t)
+
+	createModel("customer.go", []structTpl{{"Customer", "created_at", "updated_at", ""}})
+	defer os.RemoveAll("./models")
+
+	r.NoError(os.MkdirAll(filepath.Join("models", "orders"), 0755))
+	createFile(filepath.Join("orders", "order.go"), "package orders\n\ntype Order struct {\n"+
+		"ID string `db:\"id\"`\n"+
+		"}\n")
+
+	abs, err := filepath.Abs("models")
+	r.NoError(err)
+
+	//Symlinks need privileges on some platforms
+	if err := os.Symlink(filepath.Join(abs, "orders"), filepath.Join("models", "linked")); err != nil {
+		t.Skip("symlinks are not supported:", err)
+	}
+
+	r.NoError(os.Symlink(abs, filepath.Join("models", "self")))
+	r.NoError(os.Symlink(filepath.Join(abs, "customer.go"), filepath.Join("models", "customer_link.go")))
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("db")
+	m.SetRecursive(true)
+
+	r.Empty(m.Run())
+	r.Equal(2, m.Stats().FilesParsed)
+	r.Len(m.Tags(), 2)
+
+	//Every physical file is parsed once and the link back to the models folder is not walked again
+	m.SetFollowSymlinks(true)
+
+	r.Empty(m.Run())
+	r.Equal(2, m.Stats().FilesParsed)
+	r.Len(m.Tags(), 2)
+
+	//Without recursion only the files are followed
+	m.SetRecursive(false)
+
+	r.Empty(m.Run())
+	r.Equal(1, m.Stats().FilesParsed)
+	r.Len(m.TagsFor("Customer"), 3)
+}
+
+func Test_testValidateDashTags(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", `package models
+
+type Customer struct {
+	ID       int    `+"`"+`json:"id" db:"id"`+"`"+`
+	Password string `+"`"+`json:"-" db:"-"`+"`"+`
+	Token    string `+"`"+`json:"-" db:"-"`+"`"+`
+	Session  string `+"`"+`json:"-" db:"-"`+"`"+`
+	Dash     string `+"`"+`json:"-," db:"-,"`+"`"+`
+}
+`)
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors()
+	m.AddProcessor("db", NewFieldNameConsistencyProcessor(nil))
+
+	errs := m.Run()
+
+	//Only `-,` which names the field "-" is reported
+	r.Len(errs, 5)
+
+	for _, err := range errs {
+		r.Equal("Dash", err.(*ValidationError).Field)
+	}
+
+	m.SetSkipDashTags(false)
+
+	//Both rules fire on every dash tag and both tags hold duplicates
+	r.Len(m.Run(), 5+6*2+4)
+}
+
+func Test_testValidateDuplicateKeys(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", `package models
+
+type Customer struct {
+	ID   int    `+"`"+`json:"id" db:"id"`+"`"+`
+	Name string `+"`"+`json:"name" db:"name" json:"full_name"`+"`"+`
+}
+`)
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("json")
+
+	errs := m.Run()
+
+	r.Len(errs, 1)
+	r.Equal("Duplicate tag key json in Customer.Name with values name and full_name", errs[0].Error())
+
+	m.SetCheckDuplicateKeys(false)
+
+	r.Empty(m.Run())
+}
+
+func Test_testValidateAllowedValueCharset(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", `package models
+
+type Customer struct {
+	ID      int    `+"`"+`json:"id" bson:"_id"`+"`"+`
+	Address string `+"`"+`json:"homeAddress" bson:"address.home"`+"`"+`
+	Zip     string `+"`"+`json:"zipCode" bson:"address.zip."`+"`"+`
+}
+`)
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("json", "bson")
+
+	r.Len(m.Run(), 5)
+
+	r.Error(m.SetAllowedValueCharset("bson", "z-a"))
+	r.Error(m.SetAllowedValueCharset("bson", "^a-z"))
+	r.NoError(m.SetAllowedValueCharset("bson", "a-z0-9_."))
+	r.NoError(m.SetAllowedValueCharset("json", "[a-zA-Z]"))
+
+	messages := []string{}
+
+	for _, err := range m.Run() {
+		messages = append(messages, err.Error())
+	}
+
+	r.Equal([]string{
+		"Tag cannot end on . in  Customer.bson.address.zip., charset [a-z0-9_.] (suggested: address.zip)",
+	}, messages)
+}
+
+func Test_testValidateTagValueContent(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", "package models\n\n"+
+		"type Customer struct {\n"+
+		"\tID    int    \"json:\\\"id\\\" db:\\\"id\\\"\"\n"+
+		"\tName  string \"json:\\\"name\\\" i18n:\\\"Straße \\\\\\\"name\\\\\\\"\\\"\"\n"+
+		"\tLabel string `json:\"label\" i18n:\"名前\" desc:\"say \\\"hi\\\"\"`\n"+
+		"\tTime  string `json:\"time\" desc:\"format: 15:04\"`\n"+
+		"}\n")
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	tags, err := m.ListTags()
+
+	r.NoError(err)
+
+	values := []string{}
+
+	for _, tag := range tags["Customer"] {
+		values = append(values, tag.GetFieldName()+"."+tag.GetName()+":"+tag.GetValue())
+	}
+
+	r.Equal([]string{
+		"ID.json:id", "ID.db:id",
+		"Name.json:name", `Name.i18n:Straße "name"`,
+		"Label.json:label", "Label.i18n:名前", `Label.desc:say "hi"`,
+		"Time.json:time", "Time.desc:format: 15:04",
+	}, values)
+
+	m.AddDefaultProcessors("i18n")
+
+	messages := []string{}
+
+	for _, err := range m.Run() {
+		messages = append(messages, err.Error())
+	}
+
+	r.ElementsMatch([]string{
+		`Invalid symboles S in Customer.i18n.Straße "name", charset [a-z0-9_, ] (suggested: stra_e _name_)`,
+		`Tag cannot end on " in  Customer.i18n.Straße "name", charset [a-z0-9_, ] (suggested: Straße "name)`,
+		"Invalid symboles 名前 in Customer.i18n.名前, charset [a-z0-9_, ] (suggested: _)",
+		"Tag cannot end on 前 in  Customer.i18n.名前, charset [a-z0-9_, ]",
+	}, messages)
+}
+
+func BenchmarkModel_ValidateNoErrors(b *testing.B) {
+
+	//We don't want to add the struct creation time into the benchmark
+	//so we stop the timer
+	b.StopTimer()
+
+	//Let's stress the program and create 50k models
+	for i := 0; i < cnt; i++ {
+		structs := []structTpl{{
+			"Customer" + strconv.Itoa(i),
+			"created_at" + strconv.Itoa(i),
+			"updated_at" + strconv.Itoa(i),
+			"updated_at" + strconv.Itoa(i),
+		},
+		}
+
+		createModel("Customer"+strconv.Itoa(i)+".go", structs)
+	}
+
+	b.StartTimer()
 
 	for i := 0; i < 5; i++ {
 		//Lets time the meat and potatoes of the benchmark
@@ -316,3 +1117,846 @@ func BenchmarkModel_ValidateWithErrors(b *testing.B) {
 	b.StopTimer()
 	os.RemoveAll("./models")
 }
+
+func BenchmarkModel_RepeatedRuns(b *testing.B) {
+
+	//We don't want to add the struct creation time into the benchmark
+	//so we stop the timer
+	b.StopTimer()
+
+	for i := 0; i < cnt; i++ {
+		structs := []structTpl{{
+			"Customer" + strconv.Itoa(i),
+			"created_at" + strconv.Itoa(i),
+			"updated_at" + strconv.Itoa(i),
+			"updated_at" + strconv.Itoa(i),
+		},
+		}
+
+		createModel("Customer"+strconv.Itoa(i)+".go", structs)
+	}
+
+	b.StartTimer()
+
+	//One validator is reused like in watch mode, later runs reuse what the first one built
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("db", "json")
+
+	for i := 1; i <= 5; i++ {
+		b.Run("run"+strconv.Itoa(i), func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				m.Run()
+			}
+		})
+	}
+
+	//Don't want to time the deletion of the files
+	b.StopTimer()
+	os.RemoveAll("./models")
+}
+
+func BenchmarkModel_CachedRuns(b *testing.B) {
+
+	//We don't want to add the struct creation time into the benchmark
+	//so we stop the timer
+	b.StopTimer()
+
+	for i := 0; i < cnt; i++ {
+		structs := []structTpl{{
+			"Customer" + strconv.Itoa(i),
+			"created_at" + strconv.Itoa(i),
+			"updated_at" + strconv.Itoa(i),
+			"updated_at" + strconv.Itoa(i),
+		},
+		}
+
+		createModel("Customer"+strconv.Itoa(i)+".go", structs)
+	}
+
+	newValidator := func() Validator {
+		m := NewValidator(modelsPath)
+		m.AddDefaultProcessors("db", "json")
+		m.SetMemoryCache(true)
+
+		return m
+	}
+
+	//The time spent collecting the tags, listing and parsing the files included, is reported apart from running the processors
+	run := func(b *testing.B, validator func() *Validator) {
+		b.ReportAllocs()
+
+		var collect time.Duration
+
+		for i := 0; i < b.N; i++ {
+			m := validator()
+			m.Run()
+			collect += m.Stats().Duration - m.Stats().ValidateDuration
+		}
+
+		b.ReportMetric(float64(collect.Nanoseconds())/float64(b.N), "collect-ns/op")
+	}
+
+	b.StartTimer()
+
+	//Every cold run parses all the files into an empty cache
+	b.Run("cold", func(b *testing.B) {
+		run(b, func() *Validator {
+			m := newValidator()
+			return &m
+		})
+	})
+
+	//The first run fills the cache, the timed ones only look the files up
+	m := newValidator()
+	m.Run()
+
+	for i := 1; i <= 5; i++ {
+		b.Run("run"+strconv.Itoa(i), func(b *testing.B) {
+			run(b, func() *Validator { return &m })
+		})
+	}
+
+	//Don't want to time the deletion of the files
+	b.StopTimer()
+	os.RemoveAll("./models")
+}
+
+func BenchmarkModel_ValidateWithErrors(b *testing.B) {
+
+	//We don't want to add the struct creation time into the benchmark
+	//so we stop the timer
+	b.StopTimer()
+
+	//Let's stress the program and create 50k models
+	for i := 0; i < cnt; i++ {
+		structs := []structTpl{{
+			"Customer" + strconv.Itoa(i),
+			"updated_at" + strconv.Itoa(i),
+			"updated_at" + strconv.Itoa(i),
+			"updated_at" + strconv.Itoa(i),
+		},
+		}
+
+		createModel("Customer"+strconv.Itoa(i)+".go", structs)
+	}
+
+	b.StartTimer()
+
+	for i := 0; i < 5; i++ {
+		//Lets time the meat and potatoes of the benchmark
+		b.Run("subtest", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				m := NewValidator(modelsPath)
+				m.AddDefaultProcessors("db")
+				m.Run()
+			}
+		})
+	}
+
+	//Don't want to time the deletion of the files
+	b.StopTimer()
+	os.RemoveAll("./models")
+}
+
+func BenchmarkModel_ValidatePhase(b *testing.B) {
+
+	//We don't want to add the struct creation time into the benchmark
+	//so we stop the timer
+	b.StopTimer()
+
+	for i := 0; i < cnt; i++ {
+		structs := []structTpl{{
+			"Customer" + strconv.Itoa(i),
+			"created_at" + strconv.Itoa(i),
+			"updated_at" + strconv.Itoa(i),
+			"updated_at" + strconv.Itoa(i),
+		},
+		}
+
+		createModel("Customer"+strconv.Itoa(i)+".go", structs)
+	}
+
+	b.StartTimer()
+
+	//The processors of one worker against those of all of them, the validate phase is reported apart from parsing
+	workerCounts := []int{1}
+
+	if n := runtime.GOMAXPROCS(0); n > 1 {
+		workerCounts = append(workerCounts, n)
+	}
+
+	for _, workers := range workerCounts {
+		b.Run("workers"+strconv.Itoa(workers), func(b *testing.B) {
+			m := NewValidator(modelsPath)
+			m.AddDefaultProcessors("db", "json")
+			m.AddProcessor("db", NewMaxLengthProcessor(30))
+			m.AddProcessor("db", checkNotID)
+			m.AddStructProcessor("db", NewConventionProcessor([]string{"id"}, []string{"id"}))
+			m.SetConcurrency(workers)
+
+			var validate time.Duration
+
+			for i := 0; i < b.N; i++ {
+				m.Run()
+				validate += m.Stats().ValidateDuration
+			}
+
+			b.ReportMetric(float64(validate.Nanoseconds())/float64(b.N), "validate-ns/op")
+		})
+	}
+
+	//Don't want to time the deletion of the files
+	b.StopTimer()
+	os.RemoveAll("./models")
+}
+
+func Test_testValidateConcurrentProcessors(t *testing.T) {
+	r := require.New(t)
+
+	for i := 0; i < 30; i++ {
+		createModel(fmt.Sprintf("customer%v.go", i), []structTpl{{fmt.Sprintf("Customer%v", i), "created_at", "updated-at", ""}})
+	}
+
+	createFile("order.go", "package models\n\ntype Order struct {\n"+
+		"ID string `db:\"id\"`\n"+
+		"Ref string `db:\"id\"`\n"+
+		"}\n")
+	defer os.RemoveAll("./models")
+
+	run := func(workers int) ([]string, Stats, int64) {
+		var calls int64
+		var mu sync.Mutex
+
+		m := NewValidator(modelsPath)
+		m.SetConcurrency(workers)
+		m.AddDefaultProcessors("db")
+		m.AddProcessor("db", func(tag *Tag) []error {
+			mu.Lock()
+			calls++
+			mu.Unlock()
+
+			return nil
+		})
+		m.AddStructProcessor("db", func(s *StructInfo) []error {
+			return []error{fmt.Errorf("%v has %v db tags", s.Name, len(s.Tags))}
+		})
+
+		result, err := m.Validate()
+		r.NoError(err)
+
+		messages := []string{}
+
+		for _, finding := range result.Findings {
+			messages = append(messages, finding.Message)
+		}
+
+		return messages, result.Stats, calls
+	}
+
+	sequential, stats, calls := run(1)
+
+	//Struct by struct in the order of their names, the duplicate is found within Order only
+	r.Len(sequential, 30*2+2)
+	r.Equal("Invalid symboles - in Customer0.db.updated-at, charset [a-z0-9_, ]", sequential[0])
+	r.Equal("Customer0 has 3 db tags", sequential[1])
+	r.Equal("Duplicate tag value id in Order.db", sequential[60])
+	r.Equal("Order has 2 db tags", sequential[61])
+	r.Equal(int64(30*3+2), calls)
+	r.Equal(30*3+2, stats.TagsCollected["db"])
+	r.True(stats.ValidateDuration > 0)
+
+	for i := 0; i < 5; i++ {
+		parallel, parallelStats, parallelCalls := run(8)
+
+		r.Equal(sequential, parallel)
+		r.Equal(calls, parallelCalls)
+		r.Equal(stats.ProcessorsRun, parallelStats.ProcessorsRun)
+		r.Equal(stats.TagsCollected, parallelStats.TagsCollected)
+	}
+}
+
+func Test_testValidateProcessorsOneAtATimeByDefault(t *testing.T) {
+	r := require.New(t)
+
+	for i := 0; i < 10; i++ {
+		createModel(fmt.Sprintf("customer%v.go", i), []structTpl{{fmt.Sprintf("Customer%v", i), "created_at", "updated_at", ""}})
+	}
+	defer os.RemoveAll("./models")
+
+	//The most processors running at once
+	run := func(m Validator) int {
+		var running, most int
+		var mu sync.Mutex
+
+		m.AddProcessor("db", func(tag *Tag) []error {
+			mu.Lock()
+			running++
+			most = max(most, running)
+			mu.Unlock()
+
+			time.Sleep(time.Millisecond)
+
+			mu.Lock()
+			running--
+			mu.Unlock()
+
+			return nil
+		})
+
+		_, err := m.Validate()
+		r.NoError(err)
+
+		return most
+	}
+
+	r.Equal(1, run(NewValidator(modelsPath)))
+
+	m := NewValidator(modelsPath)
+	m.SetConcurrency(8)
+	r.Greater(run(m), 1)
+
+	m = NewValidator(modelsPath)
+	m.SetConcurrency(8)
+	m.SetConcurrency(0)
+	r.Equal(1, run(m))
+}
+
+func Test_testValidateBuildContext(t *testing.T) {
+	r := require.New(t)
+
+	structs := []structTpl{
+		{
+			"Customer",
+			"created_at",
+			"updated_at",
+			"",
+		},
+	}
+
+	createModel("model_linux.go", structs)
+	createModel("model_windows.go", structs)
+	defer os.RemoveAll("./models")
+
+	for _, goos := range []string{"linux", "windows"} {
+		m := NewValidator(modelsPath)
+		m.SetBuildContext(goos, "amd64", nil)
+		m.AddDefaultProcessors("db")
+
+		errs := m.Run()
+
+		r.Empty(errs)
+		r.Len(m.tags, 1)
+		r.Len(m.tags["Customer"], 3)
+	}
+}
+
+func Test_testValidateBuildTags(t *testing.T) {
+	r := require.New(t)
+
+	structs := []structTpl{
+		{
+			"Customer",
+			"created_at",
+			"updated_at",
+			"",
+		},
+	}
+
+	createModel("customer.go", structs)
+	defer os.RemoveAll("./models")
+
+	createFile("integration.go", "//go:build integration\n\n"+
+		fmt.Sprintf(declrTmp+structTmp, "Integration", "created_at", "updated_at"))
+
+	m := NewValidator(modelsPath)
+	m.SetBuildContext("linux", "amd64", nil)
+	m.AddDefaultProcessors("db")
+
+	r.Empty(m.Run())
+	r.Len(m.tags, 1)
+
+	m.SetBuildContext("linux", "amd64", []string{"integration"})
+
+	r.Empty(m.Run())
+	r.Len(m.tags, 2)
+	r.Len(m.tags["Integration"], 3)
+}
+
+func Test_testValidateGenericStructs(t *testing.T) {
+	r := require.New(t)
+
+	createFile("page.go", `package models
+
+type Page[T any] struct {
+	Items []T `+"`"+`json:"items" db:"items"`+"`"+`
+	Total int `+"`"+`json:"total" db:"total"`+"`"+`
+}
+
+type Pair[K comparable, V interface{ ~struct {
+	Name string `+"`"+`db:"constraint_name"`+"`"+`
+} }] struct {
+	Key   K `+"`"+`db:"key"`+"`"+`
+	Value V `+"`"+`db:"value"`+"`"+`
+}
+
+type CustomerPage struct {
+	Page[Customer] `+"`"+`db:"page"`+"`"+`
+	Cursor string `+"`"+`db:"cursor"`+"`"+`
+}
+`)
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("db")
+
+	r.Empty(m.Run())
+	r.Len(m.tags, 3)
+	r.Len(m.tags["Page"], 2)
+	r.Len(m.tags["Pair"], 2)
+	r.Len(m.tags["CustomerPage"], 2)
+
+	for _, tag := range m.tags["Pair"] {
+		r.NotEqual("constraint_name", tag.GetValue())
+	}
+}
+
+func Test_testValidateConcurrency(t *testing.T) {
+	r := require.New(t)
+
+	for i := 0; i < 20; i++ {
+		structs := []structTpl{{
+			"Customer" + strconv.Itoa(i),
+			"updated_at" + strconv.Itoa(i),
+			"updated_at" + strconv.Itoa(i),
+			"updated_at" + strconv.Itoa(i),
+		},
+		}
+
+		createModel("Customer"+strconv.Itoa(i)+".go", structs)
+	}
+	defer os.RemoveAll("./models")
+
+	for _, n := range []int{0, 1, 3, 64} {
+		m := NewValidator(modelsPath)
+		m.SetConcurrency(n)
+		m.AddDefaultProcessors("db")
+
+		r.Len(m.Run(), 20)
+		r.Len(m.tags, 20)
+
+		for _, tags := range m.tags {
+			r.Len(tags, 3)
+		}
+	}
+}
+
+func Test_testValidateConcurrentRuns(t *testing.T) {
+	r := require.New(t)
+
+	for i := 0; i < 10; i++ {
+		structs := []structTpl{}
+
+		for j := 0; j <= i; j++ {
+			structs = append(structs, structTpl{fmt.Sprintf("Customer%v_%v", i, j), "created_at", "created_at", ""})
+		}
+
+		createModel("Customer"+strconv.Itoa(i)+".go", structs)
+	}
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("db")
+
+	results := make([]*RunResult, 10)
+	errs := make([]error, 10)
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = m.Validate("Customer" + strconv.Itoa(i))
+		}(i)
+	}
+
+	wg.Wait()
+
+	for i, result := range results {
+		r.NoError(errs[i])
+		r.Len(result.Findings, i+1)
+		r.Equal(i+1, result.Stats.StructsFound)
+
+		for _, finding := range result.Findings {
+			r.True(strings.HasPrefix(finding.Struct, fmt.Sprintf("Customer%v_", i)))
+		}
+	}
+
+	//The tags are the ones of the run which finished last
+	r.NotEmpty(m.Tags())
+}
+
+func Test_testValidateOptionsDuringRun(t *testing.T) {
+	r := require.New(t)
+
+	createFile("customer.go", "package models\n\ntype Customer struct {\n"+
+		"ID int `db:\"ID\"`\n"+
+		"Name string `db:\",omitempty\"`\n"+
+		"}\n")
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("db")
+	m.SetConcurrency(1)
+
+	before, err := m.Validate()
+	r.NoError(err)
+	r.Len(before.Findings, 3)
+
+	//The first tag blocks the run until the options are changed
+	started := make(chan bool)
+	release := make(chan bool)
+	once := sync.Once{}
+
+	m.AddProcessorWithPriority("db", PriorityPrerequisite+1, func(tag *Tag) []error {
+		once.Do(func() {
+			started <- true
+			<-release
+		})
+
+		return nil
+	})
+
+	done := make(chan *RunResult)
+
+	go func() {
+		result, _ := m.Validate()
+		done <- result
+	}()
+
+	<-started
+	m.AllowEmptyValue("db")
+	r.NoError(m.SetAllowedValueCharset("db", "a-zA-Z,"))
+	close(release)
+
+	//The run keeps the options it started with
+	result := <-done
+	r.Equal(findingMessages(before.Findings), findingMessages(result.Findings))
+
+	result, err = m.Validate()
+	r.NoError(err)
+	r.Empty(result.Findings)
+}
+
+// findingMessages returns the messages of the findings.
+func findingMessages(findings []*ValidationError) []string {
+	messages := make([]string, 0, len(findings))
+
+	for _, finding := range findings {
+		messages = append(messages, finding.Error())
+	}
+
+	return messages
+}
+
+func Test_testValidateParseErrors(t *testing.T) {
+	r := require.New(t)
+
+	structs := []structTpl{
+		{
+			"Customer",
+			"created_at",
+			"created_at",
+			"",
+		},
+	}
+
+	createModel("customer.go", structs)
+	createFile("broken.go", "package models\n\ntype Broken struct {\n")
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("db")
+
+	errs := m.Run()
+
+	r.Len(errs, 2)
+	r.Contains(errs[0].Error(), "broken.go")
+	r.Contains(errs[1].Error(), "Duplicate tag value created_at")
+}
+
+func Test_testValidateResult(t *testing.T) {
+	r := require.New(t)
+
+	structs := []structTpl{
+		{
+			"Customer",
+			"created_at",
+			"created_at",
+			"",
+		},
+	}
+
+	createModel("customer.go", structs)
+	createFile("broken.go", "package models\n\ntype Broken struct {\n")
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("db")
+
+	result, err := m.Validate()
+
+	r.NoError(err)
+	r.True(result.HasFindings())
+	r.Len(result.Findings, 1)
+	r.Equal("Customer", result.Findings[0].Struct)
+	r.Len(result.Warnings, 1)
+	r.Contains(result.Warnings[0].Error(), "broken.go")
+	r.Equal(1, result.Stats.FilesParsed)
+	r.Equal(2, result.Stats.ErrorsProduced)
+
+	//Nothing to validate is a setup failure, not a finding
+	m = NewValidator(modelsPath)
+	m.AddDefaultProcessors("newtag")
+
+	result, err = m.Validate()
+
+	r.ErrorIs(err, ErrNoTags)
+	r.False(result.HasFindings())
+
+	errs := m.Run()
+
+	r.Len(errs, 2)
+	r.ErrorIs(errs[1], ErrNoTags)
+}
+
+func Test_testValidateProcessorPanic(t *testing.T) {
+	r := require.New(t)
+
+	createModel("customer.go", []structTpl{{"Customer", "created_at", "updated_at", ""}})
+	defer os.RemoveAll("./models")
+
+	m := NewValidator(modelsPath)
+	m.AddProcessor("db", func(tag *Tag) []error {
+		if tag.GetValue() == "updated_at" {
+			var tags map[string]*Tag
+			tags["x"].name = nil
+		}
+
+		return nil
+	})
+
+	errs := m.Run()
+
+	r.Len(errs, 1)
+	r.ErrorIs(errs[0], ErrProcessorPanic)
+
+	var finding *ValidationError
+
+	r.True(errors.As(errs[0], &finding))
+	r.Equal("Customer", finding.Struct)
+	r.Equal("UpdatedAt", finding.Field)
+	r.Contains(finding.Message, "processor panicked for tag db on Customer.UpdatedAt: runtime error: invalid memory address or nil pointer dereference")
+	r.Contains(finding.Message, "at github.com/petar-dambovaliev/struct-tag-validator.Test_testValidateProcessorPanic.func1 (")
+	r.Contains(finding.Message, "validator_test.go:")
+
+	m.SetFailFastOnPanic(true)
+
+	r.Panics(func() {
+		m.Run()
+	})
+}
+
+func Test_testValidateLogger(t *testing.T) {
+	r := require.New(t)
+
+	createModel("customer.go", []structTpl{{"Customer", "created_at", "updated_at", ""}})
+	createModel("order.go", []structTpl{{"Order", "created_at", "updated_at", ""}})
+	createFile("customer_test.go", "package models\n")
+	createFile("notes.txt", "")
+	defer os.RemoveAll("./models")
+
+	out := &bytes.Buffer{}
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("db")
+	m.SetLogger(slog.New(slog.NewTextHandler(out, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	r.Empty(m.Run("Customer"))
+
+	logs := out.String()
+
+	r.Contains(logs, `msg="resolved models path" path=`)
+	r.Contains(logs, `msg="file accepted" file=customer.go`)
+	r.Contains(logs, `msg="file rejected" file=order.go reason="not one of the models"`)
+	r.Contains(logs, `msg="file rejected" file=customer_test.go reason="test file"`)
+	r.Contains(logs, `msg="file rejected" file=notes.txt reason="not a go file"`)
+	r.Contains(logs, `msg="file parsed" file=`)
+	r.Contains(logs, `tags=3`)
+	r.Contains(logs, `msg="parsed files" files=1 failed=0 structs=1 duration=`)
+	r.Contains(logs, `msg="validated tags" findings=0 duration=`)
+}
+
+func Test_testTagKeysCache(t *testing.T) {
+	r := require.New(t)
+
+	m := NewValidator(modelsPath)
+	keys := m.tagKeys([]string{"json", "db"})
+
+	r.Equal(map[string]bool{"db": true, "json": true}, keys)
+	r.Equal(reflect.ValueOf(keys).Pointer(), reflect.ValueOf(m.tagKeys([]string{"db", "json"})).Pointer())
+	r.Equal(map[string]bool{"db": true}, m.tagKeys([]string{"db"}))
+	r.Nil(m.tagKeys([]string{"db", AllTags}))
+}
+
+func Test_testValidateContextNoLeaks(t *testing.T) {
+	r := require.New(t)
+
+	for i := 0; i < 200; i++ {
+		createModel(fmt.Sprintf("customer%v.go", i), []structTpl{{fmt.Sprintf("Customer%v", i), "created_at", "updated_at", ""}})
+	}
+
+	defer os.RemoveAll("./models")
+
+	before := runtime.NumGoroutine()
+
+	m := NewValidator(modelsPath)
+	m.AddDefaultProcessors("db")
+	m.SetConcurrency(8)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := m.ValidateContext(ctx)
+	r.ErrorIs(err, context.Canceled)
+
+	//Aborted while the files are being parsed
+	ctx, cancel = context.WithCancel(context.Background())
+	time.AfterFunc(time.Millisecond, cancel)
+
+	m.ValidateContext(ctx)
+	cancel()
+
+	deadline := time.Now().Add(time.Second)
+
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	r.LessOrEqual(runtime.NumGoroutine(), before)
+}
+
+func Test_testValidateTimeout(t *testing.T) {
+	r := require.New(t)
+
+	for i := 0; i < 20; i++ {
+		createModel(fmt.Sprintf("customer%v.go", i), []structTpl{{fmt.Sprintf("Customer%v", i), "created_at", "updated_at", ""}})
+	}
+
+	defer os.RemoveAll("./models")
+
+	before := runtime.NumGoroutine()
+
+	m := NewValidator(modelsPath)
+	m.SetConcurrency(8)
+	m.SetTimeout(100 * time.Millisecond)
+	//The 8 workers run the processor on 8 structs at once
+	m.AddProcessor("db", func(tag *Tag) []error {
+		time.Sleep(40 * time.Millisecond)
+		return []error{errors.New("slow")}
+	})
+
+	start := time.Now()
+	result, err := m.Validate()
+
+	r.ErrorIs(err, ErrTimeout)
+	r.ErrorIs(err, context.DeadlineExceeded)
+	r.Less(time.Since(start), time.Second)
+	r.True(result.Stats.Partial)
+	r.NotEmpty(result.Findings)
+	r.Less(len(result.Findings), 60)
+	r.Equal(len(result.Findings), result.Stats.ProcessorsRun)
+
+	//The caller's own cancellation isn't reported as a timeout
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err = m.ValidateContext(ctx)
+
+	r.ErrorIs(err, context.Canceled)
+	r.NotErrorIs(err, ErrTimeout)
+	r.False(result.Stats.Partial)
+
+	m.SetTimeout(0)
+	result, err = m.Validate()
+
+	r.NoError(err)
+	r.Len(result.Findings, 60)
+	r.False(result.Stats.Partial)
+
+	deadline := time.Now().Add(time.Second)
+
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	r.LessOrEqual(runtime.NumGoroutine(), before)
+}
+
+func BenchmarkModel_RetainedMemory(b *testing.B) {
+
+	//We don't want to add the struct creation time into the benchmark
+	//so we stop the timer
+	b.StopTimer()
+
+	//Let's stress the program and create 50k models
+	for i := 0; i < cnt; i++ {
+		structs := []structTpl{{
+			"Customer" + strconv.Itoa(i),
+			"created_at" + strconv.Itoa(i),
+			"updated_at" + strconv.Itoa(i),
+			"updated_at" + strconv.Itoa(i),
+		},
+		}
+
+		createModel("Customer"+strconv.Itoa(i)+".go", structs)
+	}
+
+	b.StartTimer()
+
+	for _, retainAST := range []bool{true, false} {
+		//Reports the heap still held by the validator after a run
+		b.Run("retain="+strconv.FormatBool(retainAST), func(b *testing.B) {
+			b.ReportAllocs()
+
+			var retained uint64
+
+			for i := 0; i < b.N; i++ {
+				m := NewValidator(modelsPath)
+				m.SetRetainAST(retainAST)
+				m.AddDefaultProcessors("db")
+				m.Run()
+
+				var before, after runtime.MemStats
+
+				runtime.GC()
+				runtime.ReadMemStats(&before)
+				runtime.KeepAlive(m)
+
+				m = Validator{}
+				runtime.GC()
+				runtime.ReadMemStats(&after)
+
+				retained += before.HeapAlloc - after.HeapAlloc
+			}
+
+			b.ReportMetric(float64(retained)/float64(b.N), "retained-B/op")
+		})
+	}
+
+	//Don't want to time the deletion of the files
+	b.StopTimer()
+	os.RemoveAll("./models")
+}
@@ -0,0 +1,24 @@
+package validatortest_test
+
+import (
+	"testing"
+
+	"github.com/petar-dambovaliev/struct-tag-validator/validatortest"
+)
+
+// The tags of a models package are validated along with its tests,
+// usually from a test in the models package itself where the path can be left out.
+func TestModels(t *testing.T) {
+	t.Run("db", func(t *testing.T) {
+		validatortest.Validate(t, validatortest.Path("./testdata/models"), validatortest.Tags("db"))
+	})
+
+	t.Run("json", func(t *testing.T) {
+		validatortest.Validate(t, validatortest.Path("./testdata/models"), validatortest.Tags("json"), validatortest.AllowDuplicates())
+	})
+
+	//Known findings are kept in a golden file, the tests fail when they change
+	t.Run("golden", func(t *testing.T) {
+		validatortest.ValidateGolden(t, "testdata/invalid.golden.txt", validatortest.Path("./testdata/invalid"), validatortest.Tags("db"))
+	})
+}
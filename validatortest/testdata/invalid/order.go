@@ -0,0 +1,7 @@
+package invalid
+
+type Order struct {
+	ID    int    `db:"id"`
+	Total int    `db:"total_"`
+	Note  string `db:"id"`
+}
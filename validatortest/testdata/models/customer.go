@@ -0,0 +1,7 @@
+package models
+
+type Customer struct {
+	ID        int    `db:"id" json:"id"`
+	Name      string `db:"name" json:"name"`
+	CreatedAt string `db:"created_at" json:"created_at"`
+}
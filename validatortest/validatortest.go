@@ -0,0 +1,182 @@
+// Package validatortest validates the struct tags of a package from its tests.
+//
+//	func TestTags(t *testing.T) {
+//		validatortest.Validate(t, validatortest.Tags("db", "json"))
+//	}
+//
+// Every finding fails the test with its own error, prefixed with the position of the tag value so editors link to it.
+package validatortest
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	validator "github.com/petar-dambovaliev/struct-tag-validator"
+)
+
+var update = flag.Bool("update", false, "update the golden files of ValidateGolden")
+
+// Option configures the validator of a test.
+type Option func(c *config)
+
+type config struct {
+	path       string
+	tags       []string
+	processors bool
+	setup      []func(v *validator.Validator)
+}
+
+// Path sets the models path, an import path or a directory. It defaults to the directory of the test.
+func Path(path string) Option {
+	return func(c *config) {
+		c.path = path
+	}
+}
+
+// Tags adds the default processors for the given tags.
+// The default processors are added for all tags if no tags and no processors are given.
+func Tags(tags ...string) Option {
+	return func(c *config) {
+		c.tags = append(c.tags, tags...)
+		c.processors = true
+	}
+}
+
+// AllowDuplicates skips the duplicate values check.
+func AllowDuplicates() Option {
+	return Configure(func(v *validator.Validator) {
+		v.SetAllowDuplicates(true)
+	})
+}
+
+// Processor adds a processor for the given tag, see Validator.AddProcessor.
+func Processor(tag string, processor func(t *validator.Tag) []error) Option {
+	return func(c *config) {
+		c.processors = true
+		c.setup = append(c.setup, func(v *validator.Validator) {
+			v.AddProcessor(tag, processor)
+		})
+	}
+}
+
+// Configure calls the function with the validator before the run, for the settings without an option of their own.
+func Configure(setup func(v *validator.Validator)) Option {
+	return func(c *config) {
+		c.setup = append(c.setup, setup)
+	}
+}
+
+// newValidator creates the validator described by the options.
+func newValidator(opts []Option) *validator.Validator {
+	c := &config{path: "."}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	v := validator.NewValidator(c.path)
+
+	if len(c.tags) > 0 || !c.processors {
+		v.AddDefaultProcessors(c.tags...)
+	}
+
+	for _, setup := range c.setup {
+		setup(&v)
+	}
+
+	return &v
+}
+
+// Validate runs the validator described by the options and reports every finding with t.Errorf.
+// A run which can't complete, e.g. because the models path doesn't exist, stops the test with t.Fatalf.
+func Validate(t testing.TB, opts ...Option) {
+	t.Helper()
+
+	result, err := newValidator(opts).Validate()
+
+	for _, warning := range result.Warnings {
+		t.Errorf("%v", warning)
+	}
+
+	if err != nil {
+		t.Fatalf("Validating the struct tags: %v", err)
+	}
+
+	for _, finding := range result.Findings {
+		t.Errorf("%v: %v", position(finding), finding.Error())
+	}
+}
+
+// ValidateGolden runs the validator described by the options and compares its text report with the golden file.
+// Running the tests with -update writes the report to the golden file instead.
+// File names in the report are relative to the directory of the golden file.
+func ValidateGolden(t testing.TB, goldenPath string, opts ...Option) {
+	t.Helper()
+
+	v := newValidator(opts)
+
+	if *update {
+		if err := v.WriteReportFile(goldenPath, validator.FormatText); err != nil {
+			t.Fatalf("Updating %v: %v", goldenPath, err)
+		}
+
+		return
+	}
+
+	err := v.CheckReportFile(goldenPath, validator.FormatText)
+
+	if err == nil {
+		return
+	}
+
+	if !errors.Is(err, validator.ErrStaleReport) {
+		t.Fatalf("Validating the struct tags: %v", err)
+	}
+
+	//The report is rendered next to the golden file, so the file names match
+	tmp, tmpErr := os.CreateTemp(filepath.Dir(goldenPath), ".validatortest.*.txt")
+
+	if tmpErr != nil {
+		t.Fatalf("%v, run the tests with -update to update it", err)
+	}
+
+	tmp.Close()
+	defer os.Remove(tmp.Name())
+
+	if tmpErr = v.WriteReportFile(tmp.Name(), validator.FormatText); tmpErr != nil {
+		t.Fatalf("%v, run the tests with -update to update it", err)
+	}
+
+	expected, _ := os.ReadFile(goldenPath)
+	got, _ := os.ReadFile(tmp.Name())
+
+	t.Errorf("%v, run the tests with -update to update it\n--- expected\n%s--- got\n%s", err, expected, got)
+}
+
+// position returns the position of the finding relative to the working directory, e.g. `models/order.go:5:21`.
+// It points at the tag value when it is known.
+func position(finding *validator.ValidationError) string {
+	pos := finding.Pos
+
+	if finding.ValuePos.IsValid() {
+		pos = finding.ValuePos
+	}
+
+	if len(pos.Filename) == 0 {
+		return finding.Struct
+	}
+
+	fileName := pos.Filename
+
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, fileName); err == nil {
+			fileName = rel
+		}
+	}
+
+	return fmt.Sprintf("%v:%v:%v", fileName, pos.Line, pos.Column)
+}
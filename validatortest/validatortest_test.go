@@ -0,0 +1,95 @@
+package validatortest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	validator "github.com/petar-dambovaliev/struct-tag-validator"
+)
+
+// recorder records the failures of a test instead of failing it.
+type recorder struct {
+	testing.TB
+	errors []string
+	fatal  string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...interface{}) {
+	r.fatal = fmt.Sprintf(format, args...)
+	runtime.Goexit()
+}
+
+// record runs the function as a test and returns its failures.
+func record(f func(t testing.TB)) *recorder {
+	rec := &recorder{}
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		f(rec)
+	}()
+
+	<-done
+
+	return rec
+}
+
+func Test_testValidate(t *testing.T) {
+	r := require.New(t)
+
+	rec := record(func(t testing.TB) {
+		Validate(t, Path("./testdata/invalid"), Tags("db"))
+	})
+
+	r.Empty(rec.fatal)
+	r.ElementsMatch([]string{
+		filepath.Join("testdata", "invalid", "order.go") + ":5:20: Tag cannot end on _ in  Order.db.total_, charset [a-z0-9_, ] (suggested: total)",
+		filepath.Join("testdata", "invalid", "order.go") + ":6:20: Duplicate tag value id in Order.db (suggested: rename it, the value is held by Order.ID)",
+	}, rec.errors)
+
+	rec = record(func(t testing.TB) {
+		Validate(t, Path("./testdata/invalid"), AllowDuplicates(), Processor("db", func(tag *validator.Tag) []error {
+			return nil
+		}))
+	})
+
+	r.Empty(rec.errors)
+
+	rec = record(func(t testing.TB) {
+		Validate(t, Path("./testdata/missing"))
+	})
+
+	r.True(strings.HasPrefix(rec.fatal, "Validating the struct tags: Models folder ./testdata/missing not found"))
+}
+
+func Test_testValidateGolden(t *testing.T) {
+	r := require.New(t)
+
+	golden := filepath.Join(t.TempDir(), "golden.txt")
+	r.NoError(os.WriteFile(golden, []byte("outdated\n"), 0644))
+
+	rec := record(func(t testing.TB) {
+		ValidateGolden(t, golden, Path("./testdata/models"), Tags("db"))
+	})
+
+	r.Len(rec.errors, 1)
+	r.Contains(rec.errors[0], "report file is out of date")
+	r.Contains(rec.errors[0], "--- expected\noutdated\n--- got\n0 findings in 0 structs across 0 files\n")
+
+	matches, err := filepath.Glob(filepath.Join(filepath.Dir(golden), ".validatortest.*"))
+
+	r.NoError(err)
+	r.Empty(matches)
+}